@@ -76,7 +76,8 @@ buildForWindows() {
       -platform "windows" \
       -webview2 "embed" \
       -ldflags "-X github.com/jdheim/launchee/cmd.appVersion=${projectVersion} \
-                -X github.com/jdheim/launchee/cmd.buildForJdvm=${buildForJdvm:-false}"
+                -X github.com/jdheim/launchee/cmd.buildForJdvm=${buildForJdvm:-false} \
+                -X github.com/jdheim/launchee/internal/config/yaml.configPublicKey=${LAUNCHEE_CONFIG_PUBLIC_KEY-}"
 }
 
 buildForLinux() {
@@ -86,7 +87,8 @@ buildForLinux() {
   run wails build -clean \
     -tags "webkit2_41" \
     -ldflags "-X github.com/jdheim/launchee/cmd.appVersion=${projectVersion} \
-              -X github.com/jdheim/launchee/cmd.buildForJdvm=${buildForJdvm:-false}"
+              -X github.com/jdheim/launchee/cmd.buildForJdvm=${buildForJdvm:-false} \
+              -X github.com/jdheim/launchee/internal/config/yaml.configPublicKey=${LAUNCHEE_CONFIG_PUBLIC_KEY-}"
   buildForJdvmPostAction
 }
 
@@ -0,0 +1,108 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+)
+
+// collapsedHeight is the height of the strip the dock collapses to, which reveals the dock again on hover.
+const collapsedHeight = 4
+
+type stoppable interface {
+	Stop() bool
+}
+
+var afterFunc = func(delay time.Duration, f func()) stoppable {
+	return time.AfterFunc(delay, f)
+}
+
+// autoHider collapses or hides the dock once it has been idle for the configured delay.
+var autoHider struct {
+	lock       sync.Mutex
+	config     *frontend.AutoHide
+	width      int
+	height     int
+	timer      stoppable
+	generation int
+	collapsed  bool
+}
+
+// configureAutoHide applies the auto-hide config for a dock of the given size and restarts the idle delay.
+func configureAutoHide(config *frontend.AutoHide, width int, height int) {
+	autoHider.lock.Lock()
+	autoHider.config = config
+	autoHider.width = width
+	autoHider.height = height
+	autoHider.lock.Unlock()
+	revealWindow()
+	scheduleAutoHide()
+}
+
+// scheduleAutoHide restarts the idle delay after which the dock collapses or hides.
+func scheduleAutoHide() {
+	autoHider.lock.Lock()
+	defer autoHider.lock.Unlock()
+	stopAutoHideTimer()
+	if autoHider.config == nil || !autoHider.config.Enabled {
+		return
+	}
+	generation := autoHider.generation
+	autoHider.timer = afterFunc(autoHider.config.Delay, func() { collapseWindow(generation) })
+}
+
+// collapseWindow collapses or hides the dock, unless it has been revealed since the timer started.
+func collapseWindow(generation int) {
+	autoHider.lock.Lock()
+	if generation != autoHider.generation || autoHider.config == nil {
+		autoHider.lock.Unlock()
+		return
+	}
+	autoHider.timer = nil
+	if autoHider.config.Mode == frontend.AutoHideModeHide {
+		autoHider.lock.Unlock()
+		// Hiding takes the instance lock, which may be held by a caller revealing the dock
+		hideWindow()
+		return
+	}
+	defer autoHider.lock.Unlock()
+	setWindowSize(autoHider.width, collapsedHeight)
+	autoHider.collapsed = true
+}
+
+// revealWindow expands the collapsed dock and stops the idle delay.
+func revealWindow() {
+	autoHider.lock.Lock()
+	defer autoHider.lock.Unlock()
+	stopAutoHideTimer()
+	if autoHider.collapsed {
+		setWindowSize(autoHider.width, autoHider.height)
+		autoHider.collapsed = false
+	}
+}
+
+// stopAutoHideTimer stops the idle delay, which must be called with the lock held.
+func stopAutoHideTimer() {
+	autoHider.generation++
+	if autoHider.timer != nil {
+		autoHider.timer.Stop()
+		autoHider.timer = nil
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+type timerStub struct {
+	delay   time.Duration
+	f       func()
+	stopped bool
+}
+
+func (t *timerStub) Stop() bool {
+	t.stopped = true
+	return true
+}
+
+// useTimerStub replaces the auto-hide timers with ones the test fires, and returns the last one started.
+func useTimerStub(t *testing.T) func() *timerStub {
+	t.Helper()
+	originalAfterFunc := afterFunc
+	t.Cleanup(func() {
+		afterFunc = originalAfterFunc
+		configureAutoHide(nil, 0, 0)
+	})
+	var last *timerStub
+	afterFunc = func(delay time.Duration, f func()) stoppable {
+		last = &timerStub{delay: delay, f: f}
+		return last
+	}
+	return func() *timerStub { return last }
+}
+
+func TestAutoHide(t *testing.T) {
+	lastTimer := useTimerStub(t)
+	windowImpl = stub.WindowStub{}
+
+	testCases := map[string]struct {
+		in            *frontend.AutoHide
+		wantTimer     bool
+		wantCollapsed bool
+		wantHidden    bool
+	}{
+		"nil":      {nil, false, false, false},
+		"disabled": {&frontend.AutoHide{Delay: time.Second, Mode: frontend.AutoHideModeStrip}, false, false, false},
+		"strip":    {&frontend.AutoHide{Enabled: true, Delay: time.Second, Mode: frontend.AutoHideModeStrip}, true, true, false},
+		"hide":     {&frontend.AutoHide{Enabled: true, Delay: 2 * time.Second, Mode: frontend.AutoHideModeHide}, true, false, true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			setWindowHidden(false)
+			initialTimer := lastTimer()
+			configureAutoHide(testCase.in, 100, 200)
+			timer := lastTimer()
+			if gotTimer := timer != initialTimer; gotTimer != testCase.wantTimer {
+				t.Fatalf("configureAutoHide() started timer = %t, want %t", gotTimer, testCase.wantTimer)
+			}
+			if testCase.wantTimer {
+				if timer.delay != testCase.in.Delay {
+					t.Errorf("timer delay = %v, want %v", timer.delay, testCase.in.Delay)
+				}
+				timer.f()
+			}
+			if autoHider.collapsed != testCase.wantCollapsed {
+				t.Errorf("collapsed = %t, want %t", autoHider.collapsed, testCase.wantCollapsed)
+			}
+			if isWindowHidden() != testCase.wantHidden {
+				t.Errorf("hidden = %t, want %t", isWindowHidden(), testCase.wantHidden)
+			}
+		})
+	}
+}
+
+func TestRevealWindow(t *testing.T) {
+	lastTimer := useTimerStub(t)
+	windowImpl = stub.WindowStub{}
+	launchee := NewLaunchee()
+
+	configureAutoHide(&frontend.AutoHide{Enabled: true, Delay: time.Second, Mode: frontend.AutoHideModeStrip}, 100, 200)
+	lastTimer().f()
+	if !autoHider.collapsed {
+		t.Fatal("timer did not collapse the window")
+	}
+
+	launchee.RevealWindow()
+	if autoHider.collapsed {
+		t.Error("RevealWindow() did not expand the window")
+	}
+
+	// A timer started before the window has been revealed does nothing
+	launchee.LeaveWindow()
+	staleTimer := lastTimer()
+	launchee.RevealWindow()
+	if !staleTimer.stopped {
+		t.Error("RevealWindow() did not stop the timer")
+	}
+	staleTimer.f()
+	if autoHider.collapsed {
+		t.Error("stale timer collapsed the window")
+	}
+
+	launchee.LeaveWindow()
+	lastTimer().f()
+	if !autoHider.collapsed {
+		t.Error("LeaveWindow() did not restart the timer")
+	}
+
+	// Showing the dock, e.g. with a hotkey, expands it
+	setWindowHidden(false)
+	if autoHider.collapsed {
+		t.Error("setWindowHidden(false) did not expand the window")
+	}
+}
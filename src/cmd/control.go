@@ -0,0 +1,112 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/jdheim/launchee/internal/control"
+	"github.com/jdheim/launchee/internal/lctx"
+)
+
+var controlSocketPath = control.SocketPath
+
+// controlApi exposes the dock to the control API.
+type controlApi struct {
+	launchee *Launchee
+}
+
+func (c controlApi) ListShortcuts() []*control.Shortcut {
+	shortcuts := make([]*control.Shortcut, 0)
+	if config := c.launchee.GetConfig(); config != nil {
+		for _, shortcut := range config.Shortcuts {
+			shortcuts = append(shortcuts, &control.Shortcut{
+				Id:          shortcut.Id,
+				StableId:    shortcut.StableId,
+				Name:        shortcut.Name,
+				Command:     shortcut.Command,
+				CommandArgs: shortcut.CommandArgs,
+				Url:         shortcut.Url,
+				Path:        shortcut.Path,
+			})
+		}
+	}
+	return shortcuts
+}
+
+func (c controlApi) Launch(params *control.LaunchParams) error {
+	id := -1
+	if params.Id != nil {
+		id = *params.Id
+	}
+	shortcut, err := c.launchee.findShortcutByNameOrId(params.Name, id)
+	if err != nil {
+		return err
+	}
+	return c.launchee.launchShortcut(shortcut)
+}
+
+func (c controlApi) ListProcesses() []*control.Process {
+	list := make([]*control.Process, 0)
+	for _, process := range processes.list() {
+		list = append(list, &control.Process{Shortcut: process.name, Pid: process.pid})
+	}
+	return list
+}
+
+func (c controlApi) Reload() error {
+	return c.launchee.reload()
+}
+
+func (c controlApi) SetWindowHidden(hidden bool) {
+	setWindowHidden(hidden)
+}
+
+// syncControlApi starts or stops the control API, so it matches the config.
+func (l *Launchee) syncControlApi() {
+	config := l.config()
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+	enabled := config != nil && config.ControlApi
+	if enabled && instance.controlServer == nil {
+		server, err := control.Listen(controlSocketPath(), controlApi{launchee: l})
+		if err != nil {
+			lctx.LogErrorf("Error occurred when starting the control API: %v", err)
+			return
+		}
+		instance.controlServer = server
+	} else if !enabled && instance.controlServer != nil {
+		// The config may have been reloaded through the control API itself, which cannot wait for its own request
+		go closeControlApi(instance.controlServer)
+		instance.controlServer = nil
+	}
+}
+
+func (l *Launchee) stopControlApi() {
+	instance.lock.Lock()
+	server := instance.controlServer
+	instance.controlServer = nil
+	instance.lock.Unlock()
+	// The lock is released first, as the requests being handled may need it
+	if server != nil {
+		closeControlApi(server)
+	}
+}
+
+func closeControlApi(server *control.Server) {
+	if err := server.Close(); err != nil {
+		lctx.LogErrorf("Error occurred when stopping the control API: %v", err)
+	}
+}
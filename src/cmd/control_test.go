@@ -0,0 +1,159 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/control"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+	"github.com/jdheim/launchee/internal/test/tempdir"
+)
+
+// useTestControlSocketPath points the control API socket to a temp dir.
+func useTestControlSocketPath(t *testing.T) string {
+	t.Helper()
+	originalControlSocketPath := controlSocketPath
+	t.Cleanup(func() { controlSocketPath = originalControlSocketPath })
+	path := filepath.Join(tempdir.Short(t), "launchee-control.sock")
+	controlSocketPath = func() string { return path }
+	return path
+}
+
+func TestControlApi(t *testing.T) {
+	path := useTestControlSocketPath(t)
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+	lctx.LoggerImpl = stub.LoggerStub{}
+	windowImpl = stub.WindowStub{}
+	eventsImpl = stub.EventsStub{}
+
+	testLaunchee := &Launchee{Config: &frontend.Config{ControlApi: true, Shortcuts: []*frontend.Shortcut{
+		{Id: 0, StableId: "cmd", Name: "Command", Command: "echo", CommandArgs: []string{"test"}},
+	}}}
+	testLaunchee.syncControlApi()
+	defer testLaunchee.stopControlApi()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	reader := bufio.NewReader(conn)
+
+	testCases := map[string]struct {
+		in         string
+		want       string
+		wantHidden bool
+	}{
+		"list shortcuts": {`{"id":1,"method":"listShortcuts"}`,
+			`{"id":1,"result":[{"id":0,"stableId":"cmd","name":"Command","command":"echo","commandArgs":["test"]}]}`, false},
+		"launch":              {`{"id":2,"method":"launch","params":{"name":"Command"}}`, `{"id":2}`, false},
+		"launch by stable id": {`{"id":6,"method":"launch","params":{"name":"cmd"}}`, `{"id":6}`, false},
+		"launch unknown":      {`{"id":3,"method":"launch","params":{"id":7}}`, `{"id":3,"error":"Shortcut 7 not found"}`, false},
+		"hide":                {`{"id":4,"method":"hide"}`, `{"id":4}`, true},
+		"show":                {`{"id":5,"method":"show"}`, `{"id":5}`, false},
+	}
+	// The order matters for hiding
+	for _, name := range []string{"list shortcuts", "launch", "launch by stable id", "launch unknown", "hide", "show"} {
+		testCase := testCases[name]
+		t.Run(name, func(t *testing.T) {
+			if _, err := conn.Write([]byte(testCase.in + "\n")); err != nil {
+				t.Fatal(err)
+			}
+			got, err := reader.ReadBytes('\n')
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != testCase.want+"\n" {
+				t.Errorf("response = %s, want %s", got, testCase.want)
+			}
+			if isWindowHidden() != testCase.wantHidden {
+				t.Errorf("hidden = %t, want %t", isWindowHidden(), testCase.wantHidden)
+			}
+		})
+	}
+}
+
+func TestControlApiListProcesses(t *testing.T) {
+	path := useTestControlSocketPath(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+
+	testLaunchee := &Launchee{Config: &frontend.Config{ControlApi: true}}
+	testLaunchee.syncControlApi()
+	defer testLaunchee.stopControlApi()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err = conn.Write([]byte(`{"method":"listProcesses"}` + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	var response struct {
+		Result []*control.Process `json:"result"`
+	}
+	if err = json.NewDecoder(conn).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Result == nil {
+		t.Error("listProcesses result = null, want a list")
+	}
+}
+
+func TestSyncControlApi(t *testing.T) {
+	path := useTestControlSocketPath(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+
+	testLaunchee := &Launchee{Config: &frontend.Config{}}
+	testLaunchee.syncControlApi()
+	if _, err := os.Stat(path); err == nil {
+		t.Error("syncControlApi() started the disabled control API")
+	}
+
+	testLaunchee.Config.ControlApi = true
+	testLaunchee.syncControlApi()
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("syncControlApi() did not start the control API: %v", err)
+	}
+
+	testLaunchee.Config.ControlApi = false
+	testLaunchee.syncControlApi()
+	// The control API is closed in the background
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := os.Stat(path); err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("syncControlApi() did not stop the control API")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	testLaunchee.stopControlApi()
+}
@@ -0,0 +1,96 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/jdheim/launchee/internal/dbusapi"
+	"github.com/jdheim/launchee/internal/lctx"
+)
+
+// dbusApi exposes the dock to the D-Bus service.
+type dbusApi struct {
+	launchee *Launchee
+}
+
+func (d dbusApi) SetWindowHidden(hidden bool) {
+	setWindowHidden(hidden)
+}
+
+func (d dbusApi) ToggleWindow() {
+	toggleWindow()
+}
+
+func (d dbusApi) Launch(name string) error {
+	shortcut, err := d.launchee.findShortcutByName(name)
+	if err != nil {
+		return err
+	}
+	return d.launchee.launchShortcut(shortcut)
+}
+
+func (d dbusApi) Reload() error {
+	return d.launchee.reload()
+}
+
+// syncDBus connects to or disconnects from the session bus, so it matches the config.
+func (l *Launchee) syncDBus() {
+	config := l.config()
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+	enabled := config != nil && config.DBus
+	if enabled && instance.dbusService == nil {
+		service, err := dbusapi.Connect(dbusApi{launchee: l})
+		if err != nil {
+			lctx.LogErrorf("Error occurred when starting the D-Bus service: %v", err)
+			return
+		}
+		instance.dbusService = service
+	} else if !enabled && instance.dbusService != nil {
+		// The config may have been reloaded through D-Bus itself, which still has to reply
+		go closeDBus(instance.dbusService)
+		instance.dbusService = nil
+	}
+}
+
+func (l *Launchee) stopDBus() {
+	instance.lock.Lock()
+	service := instance.dbusService
+	instance.dbusService = nil
+	instance.lock.Unlock()
+	if service != nil {
+		closeDBus(service)
+	}
+}
+
+func closeDBus(service *dbusapi.Service) {
+	if err := service.Close(); err != nil {
+		lctx.LogErrorf("Error occurred when stopping the D-Bus service: %v", err)
+	}
+}
+
+// emitShortcutLaunched signals the launch over D-Bus, if the service is running.
+func emitShortcutLaunched(name string) {
+	instance.lock.Lock()
+	service := instance.dbusService
+	instance.lock.Unlock()
+	if service == nil {
+		return
+	}
+	if err := service.EmitShortcutLaunched(name); err != nil {
+		lctx.LogErrorf("Error occurred when signalling the launch of \"%s\" Shortcut: %v", name, err)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/dbusapi"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/bus"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+func TestDBus(t *testing.T) {
+	bus.StartSessionBus(t)
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+	lctx.LoggerImpl = stub.LoggerStub{}
+	windowImpl = stub.WindowStub{}
+	eventsImpl = stub.EventsStub{}
+
+	testLaunchee := &Launchee{Config: &frontend.Config{DBus: true, Shortcuts: []*frontend.Shortcut{
+		{Id: 0, StableId: "Command", Name: "Command", Command: "echo", CommandArgs: []string{"test"}},
+	}}}
+	testLaunchee.syncDBus()
+	defer testLaunchee.stopDBus()
+
+	client, err := dbus.ConnectSessionBus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Close() }()
+	if err = client.AddMatchSignal(dbus.WithMatchInterface(dbusapi.InterfaceName)); err != nil {
+		t.Fatal(err)
+	}
+	signals := make(chan *dbus.Signal, 1)
+	client.Signal(signals)
+	object := client.Object(dbusapi.ServiceName, dbusapi.ObjectPath)
+
+	testCases := map[string]struct {
+		method     string
+		args       []any
+		wantErr    bool
+		wantHidden bool
+	}{
+		"hide":           {"Hide", nil, false, true},
+		"toggle":         {"Toggle", nil, false, false},
+		"launch unknown": {"Launch", []any{"Unknown"}, true, false},
+		"launch":         {"Launch", []any{"Command"}, false, false},
+	}
+	// The order matters for hiding
+	for _, name := range []string{"hide", "toggle", "launch unknown", "launch"} {
+		testCase := testCases[name]
+		t.Run(name, func(t *testing.T) {
+			call := object.Call(dbusapi.InterfaceName+"."+testCase.method, 0, testCase.args...)
+			if gotErr := call.Err != nil; gotErr != testCase.wantErr {
+				t.Errorf("Call(%s) = %v, want error %t", testCase.method, call.Err, testCase.wantErr)
+			}
+			if isWindowHidden() != testCase.wantHidden {
+				t.Errorf("hidden = %t, want %t", isWindowHidden(), testCase.wantHidden)
+			}
+		})
+	}
+
+	select {
+	case signal := <-signals:
+		if len(signal.Body) != 1 || signal.Body[0] != "Command" {
+			t.Errorf("ShortcutLaunched = %v, want [Command]", signal.Body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("ShortcutLaunched signal was not received")
+	}
+}
+
+func TestSyncDBus(t *testing.T) {
+	bus.StartSessionBus(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+
+	testLaunchee := &Launchee{Config: &frontend.Config{}}
+	testLaunchee.syncDBus()
+	if instance.dbusService != nil {
+		t.Error("syncDBus() started the disabled D-Bus service")
+	}
+
+	testLaunchee.Config.DBus = true
+	testLaunchee.syncDBus()
+	if instance.dbusService == nil {
+		t.Error("syncDBus() did not start the D-Bus service")
+	}
+
+	testLaunchee.Config.DBus = false
+	testLaunchee.syncDBus()
+	if instance.dbusService != nil {
+		t.Error("syncDBus() did not stop the D-Bus service")
+	}
+	// Launching without the D-Bus service does not signal anything
+	emitShortcutLaunched("Command")
+}
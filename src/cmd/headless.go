@@ -0,0 +1,86 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/ipc"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/pkg/errors"
+)
+
+const (
+	ExitCodeOk              = 0
+	ExitCodeInvalidConfig   = 1
+	ExitCodeUsage           = 2
+	ExitCodeUnknownShortcut = 3
+	ExitCodeLaunchFailed    = 4
+	ExitCodeNotRunning      = 5
+)
+
+// headless is set when a shortcut is launched from the command line, without the Wails runtime.
+var headless bool
+
+// RunHeadless launches the shortcut with the given name, or with the given id when the name is empty, without opening
+// the dock and returns the exit code.
+func RunHeadless(name string, id int) int {
+	headless = true
+	lctx.LoggerImpl = lctx.ConsoleLogger{}
+	config, err := loadConfig()
+	if err != nil {
+		printError("Invalid Config", err)
+		return ExitCodeInvalidConfig
+	}
+	launchee := &Launchee{Config: config}
+	shortcut, err := launchee.findShortcutByNameOrId(name, id)
+	if err != nil {
+		printError("Unknown Shortcut", err)
+		return ExitCodeUnknownShortcut
+	}
+	if err = launchHeadless(shortcut); err != nil {
+		printError(fmt.Sprintf("Could not launch \"%s\" Shortcut", shortcut.Name), err)
+		return ExitCodeLaunchFailed
+	}
+	recordLaunch(shortcut)
+	return ExitCodeOk
+}
+
+// ForwardedExitCode returns the exit code for the error the running dock responded with, the same one RunHeadless
+// returns for it.
+func ForwardedExitCode(err error) int {
+	var statusErr *ipc.Error
+	if errors.As(err, &statusErr) && statusErr.Status == ipc.StatusUnknownShortcut {
+		return ExitCodeUnknownShortcut
+	}
+	return ExitCodeLaunchFailed
+}
+
+// launchHeadless launches the shortcut and, unlike in the dock, waits for all its actions to start.
+func launchHeadless(shortcut *frontend.Shortcut) error {
+	if len(shortcut.Actions) != 0 {
+		return runActions(context.Background(), shortcut.Actions)
+	}
+	return startShortcut(shortcut)
+}
+
+func printError(message string, err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n", message, err)
+}
@@ -0,0 +1,123 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/ipc"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/pkg/errors"
+)
+
+const headlessTestConfig = `
+shortcuts:
+  - name: "Terminal"
+    icon: "../build/appicon.png"
+    command: "echo"
+    commandArgs: "Terminal"
+  - name: "Actions"
+    icon: "../build/appicon.png"
+    actions:
+      - command: "echo"
+      - command: "echo"
+        parallel: true
+  - name: "Folder"
+    icon: "../build/appicon.png"
+    path: "."
+`
+
+// Commands are validated against PATH, so the config of the shortcut that cannot be opened must not have any
+const headlessTestFolderConfig = `
+shortcuts:
+  - name: "Folder"
+    icon: "../build/appicon.png"
+    path: "."
+`
+
+func TestRunHeadless(t *testing.T) {
+	originalLoggerImpl := lctx.LoggerImpl
+	originalLogDir := logDir
+	defer func() {
+		headless = false
+		lctx.LoggerImpl = originalLoggerImpl
+		logDir = originalLogDir
+		customConfigPath = ""
+	}()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+
+	testCases := map[string]struct {
+		config string
+		name   string
+		id     int
+		noPath bool
+		want   int
+	}{
+		"name":           {headlessTestConfig, "Terminal", -1, false, ExitCodeOk},
+		"id":             {headlessTestConfig, "", 0, false, ExitCodeOk},
+		"actions":        {headlessTestConfig, "Actions", -1, false, ExitCodeOk},
+		"unknown name":   {headlessTestConfig, "Unknown", -1, false, ExitCodeUnknownShortcut},
+		"unknown id":     {headlessTestConfig, "", 100, false, ExitCodeUnknownShortcut},
+		"invalid config": {"title: Te", "Terminal", -1, false, ExitCodeInvalidConfig},
+		"launch failed":  {headlessTestFolderConfig, "Folder", -1, true, ExitCodeLaunchFailed},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			customConfigPath = filepath.Join(t.TempDir(), "launchee.yml")
+			if err := os.WriteFile(customConfigPath, []byte(testCase.config), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if testCase.noPath {
+				t.Setenv("PATH", t.TempDir())
+			}
+			if got := RunHeadless(testCase.name, testCase.id); got != testCase.want {
+				t.Errorf("RunHeadless(%q, %d) = %d, want %d", testCase.name, testCase.id, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestForwardedExitCode(t *testing.T) {
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+	testLaunchee := &Launchee{Config: &frontend.Config{Shortcuts: []*frontend.Shortcut{
+		{Id: 0, StableId: "Invalid", Name: "Invalid", Command: "echoo"},
+	}}}
+	testCases := map[string]struct {
+		in   error
+		want int
+	}{
+		"unknown shortcut": {testLaunchee.runRequestedShortcut(&ipc.Request{Command: ipc.CommandRun, Name: "Unknown"}), ExitCodeUnknownShortcut},
+		"launch failed":    {testLaunchee.runRequestedShortcut(&ipc.Request{Command: ipc.CommandRun, Name: "Invalid"}), ExitCodeLaunchFailed},
+		"refused":          {errors.New("Config was not reloaded"), ExitCodeLaunchFailed},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := ForwardedExitCode(testCase.in); got != testCase.want {
+				t.Errorf("ForwardedExitCode(%v) = %d, want %d", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
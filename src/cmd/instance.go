@@ -0,0 +1,189 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+
+	"github.com/jdheim/launchee/internal/control"
+	"github.com/jdheim/launchee/internal/dbusapi"
+	"github.com/jdheim/launchee/internal/ipc"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/tray"
+	"github.com/pkg/errors"
+)
+
+// EventConfigReloaded is emitted to the frontend once the config has been reloaded.
+const EventConfigReloaded = "configReloaded"
+
+var socketPath = ipc.SocketPath
+
+// instance holds the state of the running dock that the IPC requests act on.
+var instance struct {
+	lock          sync.Mutex
+	server        *ipc.Server
+	controlServer *control.Server
+	dbusService   *dbusapi.Service
+	tray          *tray.Tray
+	hidden        bool
+}
+
+// ForwardToRunningInstance sends the request to the running dock. It returns false when there is none.
+func ForwardToRunningInstance(request *ipc.Request) (bool, error) {
+	err := ipc.Send(socketPath(), request)
+	if errors.Is(err, ipc.ErrNotRunning) {
+		return false, nil
+	}
+	return true, err
+}
+
+// IsInstanceRunning tells whether a dock is running, which the commands are forwarded to.
+func IsInstanceRunning() bool {
+	return ipc.Running(socketPath())
+}
+
+// listen makes this dock the single instance. If another one has won the race in the meantime, it is shown instead.
+func (l *Launchee) listen() {
+	server, err := ipc.Listen(socketPath(), l.handleRequest)
+	if errors.Is(err, ipc.ErrAlreadyRunning) {
+		if _, err = ForwardToRunningInstance(&ipc.Request{Command: ipc.CommandShow}); err != nil {
+			lctx.LogErrorf("Error occurred when showing the running instance: %v", err)
+		}
+		windowImpl.Quit()
+		return
+	} else if err != nil {
+		lctx.LogErrorf("Error occurred when listening on %s: %v", socketPath(), err)
+		return
+	}
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+	instance.server = server
+}
+
+func (l *Launchee) stopListening() {
+	instance.lock.Lock()
+	server := instance.server
+	instance.server = nil
+	instance.lock.Unlock()
+	// The lock is released first, as the requests being handled may need it
+	if server == nil {
+		return
+	}
+	if err := server.Close(); err != nil {
+		lctx.LogErrorf("Error occurred when closing %s: %v", socketPath(), err)
+	}
+}
+
+func (l *Launchee) handleRequest(request *ipc.Request) error {
+	switch request.Command {
+	case ipc.CommandShow:
+		if err := l.switchRequestedProfile(request); err != nil {
+			return err
+		}
+		setWindowHidden(false)
+	case ipc.CommandHide:
+		setWindowHidden(true)
+	case ipc.CommandToggle:
+		if err := l.switchRequestedProfile(request); err != nil {
+			return err
+		}
+		toggleWindow()
+	case ipc.CommandRun:
+		return l.runRequestedShortcut(request)
+	case ipc.CommandReload:
+		return l.reload()
+	default:
+		return errors.Errorf("Unknown command \"%s\"", request.Command)
+	}
+	return nil
+}
+
+func setWindowHidden(hidden bool) {
+	if hidden {
+		hideWindow()
+		return
+	}
+	instance.lock.Lock()
+	windowImpl.Show()
+	instance.hidden = false
+	instance.lock.Unlock()
+	// The shown dock is expanded and auto-hides again once idle
+	revealWindow()
+	scheduleAutoHide()
+}
+
+func hideWindow() {
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+	windowImpl.Hide()
+	instance.hidden = true
+}
+
+func toggleWindow() {
+	setWindowHidden(!isWindowHidden())
+}
+
+func isWindowHidden() bool {
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+	return instance.hidden
+}
+
+// switchRequestedProfile switches to the profile of the request, unless it is not set or already the current one.
+func (l *Launchee) switchRequestedProfile(request *ipc.Request) error {
+	if request.Profile == "" || request.Profile == currentProfile() {
+		return nil
+	}
+	return l.switchProfile(request.Profile)
+}
+
+// runRequestedShortcut launches the shortcut of the request. The status of the error tells the client which exit code
+// to exit with, the same as when launching without the dock.
+func (l *Launchee) runRequestedShortcut(request *ipc.Request) error {
+	shortcut, err := l.findShortcutByNameOrId(request.Name, request.Id)
+	if err != nil {
+		return ipc.WithStatus(ipc.StatusUnknownShortcut, err)
+	}
+	if err = l.launchShortcut(shortcut); err != nil {
+		return ipc.WithStatus(ipc.StatusLaunchFailed, err)
+	}
+	return nil
+}
+
+// reloadLock serializes the reloads, so a failed profile switch restores the profile it has replaced.
+var reloadLock sync.Mutex
+
+// reload replaces the config with the one read again from the disk, unless it is invalid.
+func (l *Launchee) reload() error {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	return l.reloadConfig()
+}
+
+func (l *Launchee) reloadConfig() error {
+	config, err := loadConfig()
+	if err != nil {
+		return errors.WithMessage(err, "Config was not reloaded")
+	}
+	l.setConfig(config)
+	l.postStartup()
+	l.syncControlApi()
+	l.syncDBus()
+	l.syncTray()
+	eventsImpl.Emit(EventConfigReloaded)
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/config/yaml"
+	"github.com/jdheim/launchee/internal/ipc"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+	"github.com/jdheim/launchee/internal/test/tempdir"
+)
+
+// useTestSocketPath points the IPC socket to a temp dir.
+func useTestSocketPath(t *testing.T) {
+	t.Helper()
+	dir := tempdir.Short(t)
+	originalSocketPath := socketPath
+	t.Cleanup(func() { socketPath = originalSocketPath })
+	socketPath = func() string { return filepath.Join(dir, "launchee.sock") }
+}
+
+func TestForwardToRunningInstance(t *testing.T) {
+	useTestSocketPath(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	windowImpl = stub.WindowStub{}
+	openerImpl = stub.OpenerStub{}
+
+	request := &ipc.Request{Command: ipc.CommandShow}
+	if forwarded, err := ForwardToRunningInstance(request); forwarded || err != nil {
+		t.Errorf("ForwardToRunningInstance() = %t, %v, want false, nil", forwarded, err)
+	}
+
+	launchee := &Launchee{Config: &frontend.Config{}}
+	launchee.listen()
+	defer launchee.stopListening()
+	if forwarded, err := ForwardToRunningInstance(request); !forwarded || err != nil {
+		t.Errorf("ForwardToRunningInstance() = %t, %v, want true, nil", forwarded, err)
+	}
+	request = &ipc.Request{Command: ipc.CommandRun, Name: "Unknown"}
+	if forwarded, err := ForwardToRunningInstance(request); !forwarded || err == nil {
+		t.Errorf("ForwardToRunningInstance() = %t, %v, want true, error", forwarded, err)
+	}
+
+	// Another dock that has lost the race quits
+	(&Launchee{}).listen()
+}
+
+func TestHandleRequest(t *testing.T) {
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+
+	testLaunchee := &Launchee{Config: &frontend.Config{Shortcuts: []*frontend.Shortcut{
+		{Id: 0, StableId: "Command", Name: "Command", Command: "echo", CommandArgs: []string{"test"}},
+	}}}
+	testCases := map[string]struct {
+		in         *ipc.Request
+		wantErr    bool
+		wantHidden bool
+	}{
+		"hide":            {&ipc.Request{Command: ipc.CommandHide}, false, true},
+		"toggle hidden":   {&ipc.Request{Command: ipc.CommandToggle}, false, false},
+		"toggle shown":    {&ipc.Request{Command: ipc.CommandToggle}, false, true},
+		"show":            {&ipc.Request{Command: ipc.CommandShow}, false, false},
+		"run name":        {&ipc.Request{Command: ipc.CommandRun, Name: "Command"}, false, false},
+		"run id":          {&ipc.Request{Command: ipc.CommandRun, Id: 0}, false, false},
+		"run unknown":     {&ipc.Request{Command: ipc.CommandRun, Name: "Unknown"}, true, false},
+		"reload":          {&ipc.Request{Command: ipc.CommandReload}, false, false},
+		"unknown command": {&ipc.Request{Command: "unknown"}, true, false},
+	}
+
+	originalConfigPathImpl := yaml.ConfigPathImpl
+	defer func() { yaml.ConfigPathImpl = originalConfigPathImpl }()
+	yaml.ConfigPathImpl = stub.ConfigPathNotExistsStub{}
+	lctx.LoggerImpl = stub.LoggerStub{}
+	windowImpl = stub.WindowStub{}
+	eventsImpl = stub.EventsStub{}
+	// The order matters for toggling
+	for _, name := range []string{"hide", "toggle hidden", "toggle shown", "show", "run name", "run id", "run unknown",
+		"reload", "unknown command"} {
+		testCase := testCases[name]
+		t.Run(name, func(t *testing.T) {
+			err := testLaunchee.handleRequest(testCase.in)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("handleRequest() = %v, want error %t", err, testCase.wantErr)
+			}
+			if isWindowHidden() != testCase.wantHidden {
+				t.Errorf("handleRequest() hidden = %t, want %t", isWindowHidden(), testCase.wantHidden)
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	defer func() { customConfigPath = "" }()
+	lctx.LoggerImpl = stub.LoggerStub{}
+	windowImpl = stub.WindowStub{}
+	eventsImpl = stub.EventsStub{}
+
+	customConfigPath = stub.ConfigPathInvalidStub{}.GetSystemConfigPath()
+	testLaunchee := &Launchee{Config: frontend.NewConfig(0)}
+	if err := testLaunchee.reload(); err == nil {
+		t.Error("reload() = error expected")
+	}
+	if !testLaunchee.Config.Valid {
+		t.Error("reload() replaced the config with an invalid one")
+	}
+}
@@ -18,13 +18,20 @@ package cmd
 
 import (
 	"context"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/jdheim/launchee/internal/config/frontend"
 	"github.com/jdheim/launchee/internal/config/yaml"
 	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/stats"
 	"github.com/jdheim/launchee/internal/util"
+	"github.com/pkg/errors"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -39,6 +46,10 @@ var buildForJdvm string
 
 var customConfigPath string
 
+// configLock guards the config and the profile, which the IPC, D-Bus and control goroutines replace while the UI reads
+// them.
+var configLock sync.RWMutex
+
 func NewLaunchee() *Launchee {
 	return &Launchee{}
 }
@@ -48,6 +59,9 @@ type Window interface {
 	SetSize(width int, height int)
 	SetMinSize(width int, height int)
 	SetMaxSize(width int, height int)
+	Show()
+	Hide()
+	Minimise()
 	Quit()
 }
 
@@ -69,37 +83,142 @@ func (windowRuntime) SetMaxSize(width int, height int) {
 	runtime.WindowSetMaxSize(lctx.GetContext(), width, height)
 }
 
+func (windowRuntime) Show() {
+	runtime.WindowShow(lctx.GetContext())
+}
+
+func (windowRuntime) Hide() {
+	runtime.WindowHide(lctx.GetContext())
+}
+
+func (windowRuntime) Minimise() {
+	runtime.WindowMinimise(lctx.GetContext())
+}
+
 func (windowRuntime) Quit() {
 	runtime.Quit(lctx.GetContext())
 }
 
 var windowImpl Window = windowRuntime{}
 
+// Opener opens URLs and paths with the desktop's default handlers.
+type Opener interface {
+	OpenURL(url string) error
+	OpenPath(path string) error
+}
+
+type openerRuntime struct{}
+
+func (openerRuntime) OpenURL(url string) error {
+	if isWebUrl(url) && !headless {
+		runtime.BrowserOpenURL(lctx.GetContext(), url)
+		return nil
+	}
+	return startOpenCommand(url)
+}
+
+func (openerRuntime) OpenPath(path string) error {
+	return startOpenCommand(path)
+}
+
+var openerImpl Opener = openerRuntime{}
+
+type Clipboard interface {
+	SetText(text string) error
+}
+
+type clipboardRuntime struct{}
+
+func (clipboardRuntime) SetText(text string) error {
+	return runtime.ClipboardSetText(lctx.GetContext(), text)
+}
+
+var clipboardImpl Clipboard = clipboardRuntime{}
+
+type Events interface {
+	Emit(name string, data ...interface{})
+}
+
+type eventsRuntime struct{}
+
+func (eventsRuntime) Emit(name string, data ...interface{}) {
+	runtime.EventsEmit(lctx.GetContext(), name, data...)
+}
+
+var eventsImpl Events = eventsRuntime{}
+
+// UsageRecorder counts the launches of the shortcuts, which they can be sorted by.
+type UsageRecorder interface {
+	Record(name string, at time.Time) error
+}
+
+var usageRecorderImpl UsageRecorder = stats.NewStore(stats.Path)
+
+// CommandStarter starts the commands of the shortcuts, actions and menu items.
+type CommandStarter interface {
+	Start(command string, commandArgs []string, stdout io.Writer, stderr io.Writer) (*exec.Cmd, error)
+}
+
+type commandStarterRuntime struct{}
+
+func (commandStarterRuntime) Start(command string, commandArgs []string, stdout io.Writer, stderr io.Writer) (*exec.Cmd, error) {
+	cmd := exec.Command(command, commandArgs...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+var commandStarterImpl CommandStarter = commandStarterRuntime{}
+
 // Startup is called when the app starts. The context is saved so we can call the runtime methods
 func (l *Launchee) Startup(ctx context.Context) {
 	defer util.Measure("Startup")()
 	lctx.SetContext(ctx)
-	var config *frontend.Config
-	var err error
-	if customConfigPath != "" {
-		config, err = yaml.UnmarshalCustomConfig(customConfigPath)
-	} else {
-		config, err = yaml.UnmarshalConfigs()
-	}
-	l.Config = config
+	config, err := loadConfig()
 	if err != nil {
-		l.Config.Valid = false
+		config.Valid = false
+		l.setConfig(config)
 		lctx.NewErrorMessageDialog("Error occurred during application startup", err)
 		windowImpl.Quit()
 		return
 	}
+	l.setConfig(config)
 	l.postStartup()
+	l.listen()
+	l.syncControlApi()
+	l.syncDBus()
+	l.syncTray()
+}
+
+// Shutdown is called when the app is about to quit.
+func (l *Launchee) Shutdown(_ context.Context) {
+	l.stopTray()
+	l.stopDBus()
+	l.stopControlApi()
+	l.stopListening()
+}
+
+// loadConfig loads the custom config or merges the system and user configs.
+func loadConfig() (*frontend.Config, error) {
+	if customConfigPath != "" {
+		return yaml.UnmarshalCustomConfig(customConfigPath, currentProfile())
+	}
+	return yaml.UnmarshalConfigs(currentProfile())
 }
 
 func (l *Launchee) postStartup() {
-	width := l.Config.UI.Width()
-	height := l.Config.UI.Height(len(l.Config.Shortcuts))
-	windowImpl.SetTitle(l.Config.UI.Nav.Title)
+	config := l.config()
+	width := config.UI.Width()
+	height := config.UI.Height(len(config.Shortcuts))
+	windowImpl.SetTitle(config.UI.Nav.Title)
+	setWindowSize(width, height)
+	configureAutoHide(config.AutoHide, width, height)
+}
+
+func setWindowSize(width int, height int) {
 	windowImpl.SetSize(width, height)
 	windowImpl.SetMinSize(width, height)
 	windowImpl.SetMaxSize(width, height)
@@ -122,20 +241,264 @@ func (l *Launchee) SetCustomConfigPath(newCustomConfigPath string) {
 }
 
 func (l *Launchee) GetConfig() *frontend.Config {
+	return l.config()
+}
+
+// config returns the config, which is replaced as a whole by the reloads, so it is only read under the lock.
+func (l *Launchee) config() *frontend.Config {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return l.Config
 }
 
+func (l *Launchee) setConfig(config *frontend.Config) {
+	configLock.Lock()
+	defer configLock.Unlock()
+	l.Config = config
+}
+
+// HideWindow hides the dock, which stays reachable from the tray.
+func (l *Launchee) HideWindow() {
+	setWindowHidden(true)
+}
+
+// RevealWindow expands the dock collapsed by auto-hide, e.g. when the mouse enters it.
+func (l *Launchee) RevealWindow() {
+	revealWindow()
+}
+
+// LeaveWindow restarts the auto-hide idle delay once the mouse leaves the dock.
+func (l *Launchee) LeaveWindow() {
+	scheduleAutoHide()
+}
+
+// GetRecentItems reloads the recent section, which changes as files are used and shortcuts are launched.
+func (l *Launchee) GetRecentItems() []*frontend.RecentItem {
+	config := l.config()
+	if config == nil || config.Recent == nil {
+		return nil
+	}
+	return yaml.LoadRecentItems(config.Recent, config.Shortcuts)
+}
+
+// OpenRecentFile opens the file of the recent section with the desktop's default application.
+func (l *Launchee) OpenRecentFile(path string) {
+	if err := openerImpl.OpenPath(path); err != nil {
+		lctx.NewErrorMessageDialog("Error occurred when opening a recent file", err)
+	}
+}
+
 func (l *Launchee) RunCommand(command string, commandArgs []string) {
-	cmd := exec.Command(command, commandArgs...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Start(); err != nil {
+	if err := runCommand(command, commandArgs); err != nil {
 		lctx.NewErrorMessageDialog("Error occurred when running a command", err)
+	}
+}
+
+// runningActions are the actions run in the background, which tests wait for.
+var runningActions sync.WaitGroup
+
+// waitingCommands are the started commands waited for in the background, which tests wait for as well.
+var waitingCommands sync.WaitGroup
+
+func (l *Launchee) RunActions(actions []*frontend.Action) {
+	runningActions.Add(1)
+	go func() {
+		defer runningActions.Done()
+		if err := runActions(lctx.GetContext(), actions); err != nil {
+			lctx.NewErrorMessageDialog("Error occurred when running actions", err)
+		}
+	}()
+}
+
+func (l *Launchee) RunShortcut(id int) {
+	shortcut, err := l.findShortcut(id)
+	if err == nil {
+		err = l.launchShortcut(shortcut)
+	}
+	if err != nil {
+		lctx.NewErrorMessageDialog("Error occurred when running a shortcut", err)
 		return
 	}
+	l.afterLaunch(shortcut)
+}
+
+// afterLaunch gets the dock out of the way once the shortcut clicked in it has been launched.
+func (l *Launchee) afterLaunch(shortcut *frontend.Shortcut) {
+	config := l.config()
+	afterLaunch := shortcut.AfterLaunch
+	if afterLaunch == "" && config.Behavior != nil {
+		afterLaunch = config.Behavior.AfterLaunch
+	}
+	switch afterLaunch {
+	case frontend.AfterLaunchMinimise:
+		windowImpl.Minimise()
+	case frontend.AfterLaunchHide:
+		setWindowHidden(true)
+	case frontend.AfterLaunchQuit:
+		windowImpl.Quit()
+	}
+}
+
+func (l *Launchee) RunMenuItem(shortcutId int, menuItemIndex int) {
+	shortcut, err := l.findShortcut(shortcutId)
+	if err == nil {
+		if menuItemIndex < 0 || menuItemIndex >= len(shortcut.MenuItems) {
+			err = errors.Errorf("\"%s\" Shortcut has no Menu Item %d", shortcut.Name, menuItemIndex)
+		} else {
+			err = l.runMenuItem(shortcut, shortcut.MenuItems[menuItemIndex])
+		}
+	}
+	if err != nil {
+		lctx.NewErrorMessageDialog("Error occurred when running a menu item", err)
+	}
+}
+
+func (l *Launchee) findShortcut(id int) (*frontend.Shortcut, error) {
+	config := l.config()
+	if config != nil {
+		for _, shortcut := range config.Shortcuts {
+			if shortcut.Id == id {
+				return shortcut, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("Shortcut %d not found", id)
+}
+
+// findShortcutByName finds the shortcut by its name or, when no shortcut has that name, by its stable id.
+func (l *Launchee) findShortcutByName(name string) (*frontend.Shortcut, error) {
+	config := l.config()
+	if config != nil {
+		for _, shortcut := range config.Shortcuts {
+			if shortcut.Name == name {
+				return shortcut, nil
+			}
+		}
+		for _, shortcut := range config.Shortcuts {
+			if shortcut.StableId == name {
+				return shortcut, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("Shortcut \"%s\" not found", name)
+}
+
+// findShortcutByNameOrId finds the shortcut by its name or, when the name is empty, by its id.
+func (l *Launchee) findShortcutByNameOrId(name string, id int) (*frontend.Shortcut, error) {
+	if name != "" {
+		return l.findShortcutByName(name)
+	}
+	return l.findShortcut(id)
+}
+
+func (l *Launchee) launchShortcut(shortcut *frontend.Shortcut) error {
+	if len(shortcut.Actions) != 0 {
+		l.RunActions(shortcut.Actions)
+	} else if err := startShortcut(shortcut); err != nil {
+		return err
+	}
+	recordLaunch(shortcut)
+	emitShortcutLaunched(shortcut.Name)
+	return nil
+}
+
+// recordLaunch records the launch by the stable id of the shortcut, so its usage survives renaming it.
+func recordLaunch(shortcut *frontend.Shortcut) {
+	if err := usageRecorderImpl.Record(shortcut.StableId, time.Now()); err != nil {
+		lctx.LogErrorf("Error occurred when recording the launch of \"%s\" Shortcut: %v", shortcut.Name, err)
+	}
+}
+
+// startShortcut starts the command of the shortcut or opens its path or URL.
+func startShortcut(shortcut *frontend.Shortcut) error {
+	switch {
+	case shortcut.Command != "":
+		return startShortcutCommand(shortcut)
+	case shortcut.Path != "":
+		return openerImpl.OpenPath(shortcut.Path)
+	case shortcut.Browser != nil:
+		return runCommand(shortcut.Browser.Command, shortcut.Browser.Args(shortcut.Url))
+	default:
+		return openerImpl.OpenURL(shortcut.Url)
+	}
+}
+
+func (l *Launchee) runMenuItem(shortcut *frontend.Shortcut, menuItem *frontend.MenuItem) error {
+	switch menuItem.BuiltIn {
+	case frontend.MenuItemOpenContainingFolder:
+		return openContainingFolder(shortcut.Command)
+	case frontend.MenuItemCopyCommand:
+		return clipboardImpl.SetText(toCommandLine(shortcut.Command, shortcut.CommandArgs))
+	case frontend.MenuItemShowLog:
+		return showLog(shortcut)
+	case frontend.MenuItemStopProcess:
+		return processes.stop(shortcut.StableId)
+	}
+	switch {
+	case menuItem.Command != "":
+		l.RunCommand(menuItem.Command, menuItem.CommandArgs)
+	case menuItem.Path != "":
+		return openerImpl.OpenPath(menuItem.Path)
+	default:
+		return openerImpl.OpenURL(menuItem.Url)
+	}
+	return nil
+}
+
+func openContainingFolder(command string) error {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return err
+	}
+	if resolvedPath, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolvedPath
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return err
+	}
+	return openerImpl.OpenPath(filepath.Dir(path))
+}
+
+func showLog(shortcut *frontend.Shortcut) error {
+	path := logPath(shortcut.StableId)
+	if _, err := os.Stat(path); err != nil {
+		return errors.Errorf("\"%s\" Shortcut has no log yet", shortcut.Name)
+	}
+	return openerImpl.OpenPath(path)
+}
+
+// toCommandLine joins the command and its arguments, quoting the ones a shell would split.
+func toCommandLine(command string, commandArgs []string) string {
+	parts := make([]string, 0, len(commandArgs)+1)
+	for _, part := range append([]string{command}, commandArgs...) {
+		if part == "" || strings.ContainsAny(part, " \t\n'\"\\$`") {
+			part = "'" + strings.ReplaceAll(part, "'", `'\''`) + "'"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// runCommand starts the command without waiting for it.
+func runCommand(command string, commandArgs []string) error {
+	cmd, err := startCommand(command, commandArgs)
+	if err != nil {
+		return err
+	}
+	waitingCommands.Add(1)
 	go func() {
+		defer waitingCommands.Done()
 		if err := cmd.Wait(); err != nil {
 			lctx.LogErrorf("Error occurred when finishing a command %v: %v", cmd, err)
 		}
 	}()
+	return nil
+}
+
+func startCommand(command string, commandArgs []string) (*exec.Cmd, error) {
+	return startCommandWithOutput(command, commandArgs, os.Stdout, os.Stderr)
+}
+
+func startCommandWithOutput(command string, commandArgs []string, stdout io.Writer, stderr io.Writer) (*exec.Cmd, error) {
+	return commandStarterImpl.Start(command, commandArgs, stdout, stderr)
 }
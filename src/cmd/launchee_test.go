@@ -18,6 +18,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
 	"testing"
 	"time"
 
@@ -29,6 +33,12 @@ import (
 	"github.com/jdheim/launchee/internal/test/stub"
 )
 
+func TestMain(m *testing.M) {
+	// The launches of the tests must not end up in the usage stats of the user
+	usageRecorderImpl = stub.UsageStoreStub{}
+	os.Exit(m.Run())
+}
+
 func TestNewLaunchee(t *testing.T) {
 	got := NewLaunchee()
 	want := &Launchee{}
@@ -53,6 +63,12 @@ func TestWindowRuntime(t *testing.T) {
 		"WindowSetMaxSize": {func() {
 			windowRuntime{}.SetMaxSize(0, 0)
 		}},
+		"WindowShow": {func() {
+			windowRuntime{}.Show()
+		}},
+		"WindowHide": {func() {
+			windowRuntime{}.Hide()
+		}},
 		"Quit": {func() {
 			windowRuntime{}.Quit()
 		}},
@@ -68,8 +84,49 @@ func TestWindowRuntime(t *testing.T) {
 	}
 }
 
+func TestOpenerRuntime(t *testing.T) {
+	lctx.SetContext(stub.ContextStub{}.New())
+	if err := assert.FuncPanic(t, "OpenURL()", "runtime.BrowserOpenURL", func() {
+		_ = openerRuntime{}.OpenURL("https://example.com")
+	}); err != nil {
+		t.Error(err)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if err := (openerRuntime{}).OpenURL("mailto:dev@example.com"); err == nil {
+		t.Error("OpenURL() = error expected")
+	}
+	if err := (openerRuntime{}).OpenPath(t.TempDir()); err == nil {
+		t.Error("OpenPath() = error expected")
+	}
+}
+
+func TestEventsRuntime(t *testing.T) {
+	// Unlike the other runtime methods, EventsEmit exits instead of panicking without the Wails context
+	if err := assert.FuncExited(t, &assert.TestedFunc{
+		FunctionName: "Emit",
+		Function: func() {
+			lctx.SetContext(stub.ContextStub{}.New())
+			eventsRuntime{}.Emit("")
+		},
+		ExitCode: 1,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClipboardRuntime(t *testing.T) {
+	lctx.SetContext(stub.ContextStub{}.New())
+	if err := assert.FuncPanic(t, "SetText()", "runtime.ClipboardSetText", func() {
+		_ = clipboardRuntime{}.SetText("")
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestStartup(t *testing.T) {
 	testCases := []string{"valid", "invalid", "customConfigPath"}
+	useTestSocketPath(t)
 
 	for _, name := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -85,13 +142,17 @@ func TestStartup(t *testing.T) {
 				lctx.MessageDialogImpl = stub.MessageDialogErrorStub{}
 				yaml.ConfigPathImpl = stub.ConfigPathInvalidStub{}
 			}
-			NewLaunchee().Startup(stub.ContextStub{}.New())
+			launchee := NewLaunchee()
+			launchee.Startup(stub.ContextStub{}.New())
+			launchee.Shutdown(stub.ContextStub{}.New())
 		})
 	}
 
 	lctx.LoggerImpl = stub.LoggerStub{}
 	windowImpl = stub.WindowStub{}
-	NewLaunchee().Startup(stub.ContextStub{}.New())
+	launchee := NewLaunchee()
+	launchee.Startup(stub.ContextStub{}.New())
+	launchee.Shutdown(stub.ContextStub{}.New())
 }
 
 func TestGetAppVersion(t *testing.T) {
@@ -131,6 +192,37 @@ func TestGetConfig(t *testing.T) {
 	}
 }
 
+func TestGetRecentItems(t *testing.T) {
+	originalUsageStoreImpl := yaml.UsageStoreImpl
+	defer func() { yaml.UsageStoreImpl = originalUsageStoreImpl }()
+	yaml.UsageStoreImpl = stub.UsageStoreStub{}
+	shortcuts := []*frontend.Shortcut{{Id: 0, StableId: "Firefox", Name: "Firefox"}, {Id: 1, StableId: "Terminal", Name: "Terminal"}, {Id: 2, StableId: "Editor", Name: "Editor"}}
+	testCases := map[string]struct {
+		config *frontend.Config
+		want   []*frontend.RecentItem
+	}{
+		"no config": {nil, nil},
+		"no recent": {&frontend.Config{Shortcuts: shortcuts}, nil},
+		"launches": {&frontend.Config{Shortcuts: shortcuts, Recent: &frontend.Recent{Limit: 5, Launches: true}},
+			[]*frontend.RecentItem{{Name: "Terminal", ShortcutId: 1}, {Name: "Firefox", ShortcutId: 0}}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := (&Launchee{Config: testCase.config}).GetRecentItems()
+			if diff := cmp.Diff(testCase.want, got); diff != "" {
+				t.Errorf("GetRecentItems() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOpenRecentFile(t *testing.T) {
+	lctx.MessageDialogImpl = stub.MessageDialogValidStub{}
+	openerImpl = stub.OpenerStub{}
+	NewLaunchee().OpenRecentFile("/home/user/notes.txt")
+}
+
 func TestRunCommand(t *testing.T) {
 	testCases := map[string]struct {
 		command     string
@@ -148,7 +240,324 @@ func TestRunCommand(t *testing.T) {
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
 			testLaunchee.RunCommand(testCase.command, testCase.commandArgs)
-			time.Sleep(100 * time.Millisecond)
+			waitingCommands.Wait()
+		})
+	}
+}
+
+// commandRecorder records the commands started instead of running them. Existing commands start "true", so they can
+// still be waited for.
+type commandRecorder struct {
+	lock     sync.Mutex
+	commands [][]string
+	started  chan struct{}
+}
+
+func useCommandRecorder(t *testing.T) *commandRecorder {
+	t.Helper()
+	recorder := &commandRecorder{started: make(chan struct{}, 10)}
+	originalCommandStarterImpl := commandStarterImpl
+	t.Cleanup(func() {
+		runningActions.Wait()
+		waitingCommands.Wait()
+		commandStarterImpl = originalCommandStarterImpl
+	})
+	commandStarterImpl = recorder
+	return recorder
+}
+
+func (r *commandRecorder) Start(command string, commandArgs []string, stdout io.Writer, stderr io.Writer) (*exec.Cmd, error) {
+	r.lock.Lock()
+	r.commands = append(r.commands, append([]string{command}, commandArgs...))
+	r.lock.Unlock()
+	defer func() { r.started <- struct{}{} }()
+	if _, err := exec.LookPath(command); err != nil {
+		return nil, err
+	}
+	return commandStarterRuntime{}.Start("true", nil, stdout, stderr)
+}
+
+// wait returns the commands once the count of them has been started and the actions have finished.
+func (r *commandRecorder) wait(t *testing.T, count int) [][]string {
+	t.Helper()
+	for range count {
+		select {
+		case <-r.started:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("started commands = %v, want %d", r.commands, count)
+		}
+	}
+	runningActions.Wait()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.commands
+}
+
+func TestLauncheeRunActions(t *testing.T) {
+	testCases := map[string]struct {
+		actions []*frontend.Action
+		want    [][]string
+	}{
+		"valid": {
+			[]*frontend.Action{{Command: "echo", CommandArgs: []string{"test"}}, {Url: "https://example.com"}},
+			[][]string{{"echo", "test"}},
+		},
+		"in order": {
+			[]*frontend.Action{{Command: "echo", CommandArgs: []string{"first"}}, {Command: "printf", CommandArgs: []string{"second"}}},
+			[][]string{{"echo", "first"}, {"printf", "second"}},
+		},
+		"invalid": {
+			[]*frontend.Action{{Command: "echoo"}, {Command: "echo"}},
+			[][]string{{"echoo"}},
+		},
+	}
+
+	testLaunchee := &Launchee{}
+	lctx.SetContext(stub.ContextStub{}.New())
+	lctx.LoggerImpl = stub.LoggerStub{}
+	lctx.MessageDialogImpl = stub.MessageDialogValidStub{}
+	openerImpl = stub.OpenerStub{}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recorder := useCommandRecorder(t)
+			testLaunchee.RunActions(testCase.actions)
+			if diff := cmp.Diff(testCase.want, recorder.wait(t, len(testCase.want))); diff != "" {
+				t.Errorf("RunActions() started commands = diff -want +got\n%s", diff)
+			}
 		})
 	}
 }
+
+func TestRunShortcut(t *testing.T) {
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+
+	testLaunchee := &Launchee{Config: &frontend.Config{Shortcuts: []*frontend.Shortcut{
+		{Id: 0, StableId: "Command", Name: "Command", Command: "echo", CommandArgs: []string{"test"}},
+		{Id: 1, StableId: "Url", Name: "Url", Url: "https://example.com"},
+		{Id: 2, StableId: "Actions", Name: "Actions", Actions: []*frontend.Action{{Command: "echo"}}},
+		{Id: 3, StableId: "Invalid", Name: "Invalid", Command: "echoo"},
+		{Id: 4, StableId: "Path", Name: "Path", Path: testLogDir},
+		{Id: 5, StableId: "Browser", Name: "Browser", Url: "https://example.com", Browser: &frontend.Browser{Command: "echo", CommandArgs: []string{"--new-tab", "{url}"}}},
+	}}}
+	testCases := map[string]struct {
+		id   int
+		want [][]string
+	}{
+		"command":   {0, [][]string{{"echo", "test"}}},
+		"url":       {1, nil},
+		"actions":   {2, [][]string{{"echo"}}},
+		"invalid":   {3, [][]string{{"echoo"}}},
+		"path":      {4, nil},
+		"browser":   {5, [][]string{{"echo", "--new-tab", "https://example.com"}}},
+		"not found": {6, nil},
+	}
+
+	lctx.SetContext(stub.ContextStub{}.New())
+	lctx.LoggerImpl = stub.LoggerStub{}
+	lctx.MessageDialogImpl = stub.MessageDialogValidStub{}
+	openerImpl = stub.OpenerStub{}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recorder := useCommandRecorder(t)
+			testLaunchee.RunShortcut(testCase.id)
+			if diff := cmp.Diff(testCase.want, recorder.wait(t, len(testCase.want))); diff != "" {
+				t.Errorf("RunShortcut(%d) started commands = diff -want +got\n%s", testCase.id, diff)
+			}
+		})
+	}
+	NewLaunchee().RunShortcut(0)
+}
+
+// windowRecorder records the calls that get the window out of the way.
+type windowRecorder struct {
+	stub.WindowStub
+	calls []string
+}
+
+func (w *windowRecorder) Hide() {
+	w.calls = append(w.calls, "Hide")
+}
+
+func (w *windowRecorder) Minimise() {
+	w.calls = append(w.calls, "Minimise")
+}
+
+func (w *windowRecorder) Quit() {
+	w.calls = append(w.calls, "Quit")
+}
+
+func TestRunShortcutAfterLaunch(t *testing.T) {
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+
+	testCases := map[string]struct {
+		behavior    *frontend.Behavior
+		afterLaunch string
+		command     string
+		want        []string
+	}{
+		"default":           {nil, "", "echo", nil},
+		"keep":              {&frontend.Behavior{AfterLaunch: frontend.AfterLaunchHide}, frontend.AfterLaunchKeep, "echo", nil},
+		"minimise":          {nil, frontend.AfterLaunchMinimise, "echo", []string{"Minimise"}},
+		"hide":              {nil, frontend.AfterLaunchHide, "echo", []string{"Hide"}},
+		"quit":              {nil, frontend.AfterLaunchQuit, "echo", []string{"Quit"}},
+		"config":            {&frontend.Behavior{AfterLaunch: frontend.AfterLaunchQuit}, "", "echo", []string{"Quit"}},
+		"shortcut override": {&frontend.Behavior{AfterLaunch: frontend.AfterLaunchQuit}, frontend.AfterLaunchHide, "echo", []string{"Hide"}},
+		"launch failed":     {nil, frontend.AfterLaunchQuit, "echoo", nil},
+	}
+
+	lctx.LoggerImpl = stub.LoggerStub{}
+	lctx.MessageDialogImpl = stub.MessageDialogValidStub{}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			window := &windowRecorder{}
+			windowImpl = window
+			defer func() { windowImpl = stub.WindowStub{} }()
+			testLaunchee := &Launchee{Config: &frontend.Config{Behavior: testCase.behavior, Shortcuts: []*frontend.Shortcut{
+				{Id: 0, StableId: "Command", Name: "Command", Command: testCase.command, AfterLaunch: testCase.afterLaunch},
+			}}}
+			testLaunchee.RunShortcut(0)
+			if diff := cmp.Diff(testCase.want, window.calls); diff != "" {
+				t.Errorf("RunShortcut() window calls = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunMenuItem(t *testing.T) {
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+
+	menuItems := append([]*frontend.MenuItem{
+		{Label: "Command", Command: "echo", CommandArgs: []string{"test"}},
+		{Label: "Url", Url: "https://example.com"},
+		{Label: "Path", Path: testLogDir},
+	}, frontend.NewBuiltInMenuItems()...)
+	testLaunchee := &Launchee{Config: &frontend.Config{Shortcuts: []*frontend.Shortcut{
+		{Id: 0, StableId: "Sleep", Name: "Sleep", Command: "sleep", CommandArgs: []string{"10"}, MenuItems: menuItems},
+		{Id: 1, StableId: "Invalid", Name: "Invalid", Command: "invalid", MenuItems: frontend.NewBuiltInMenuItems()},
+	}}}
+	testCases := map[string]struct {
+		shortcutId    int
+		menuItemIndex int
+		wantErr       bool
+	}{
+		"command":                        {0, 0, false},
+		"url":                            {0, 1, false},
+		"path":                           {0, 2, false},
+		"open containing folder":         {0, 3, false},
+		"copy command":                   {0, 4, false},
+		"show log":                       {0, 5, false},
+		"stop process":                   {0, 6, false},
+		"stop not running process":       {1, 3, true},
+		"show not existing log":          {1, 2, true},
+		"open invalid containing folder": {1, 0, true},
+		"not found":                      {0, 7, true},
+		"negative":                       {0, -1, true},
+		"shortcut not found":             {2, 0, true},
+	}
+
+	lctx.SetContext(stub.ContextStub{}.New())
+	lctx.LoggerImpl = stub.LoggerStub{}
+	openerImpl = stub.OpenerStub{}
+	clipboardImpl = stub.ClipboardStub{}
+	// The sleep is stopped by the stop process case
+	t.Cleanup(waitingCommands.Wait)
+	testLaunchee.RunShortcut(0)
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			lctx.MessageDialogImpl = stub.MessageDialogValidStub{}
+			shortcut, err := testLaunchee.findShortcut(testCase.shortcutId)
+			if err == nil {
+				if testCase.menuItemIndex < 0 || testCase.menuItemIndex >= len(shortcut.MenuItems) {
+					testLaunchee.RunMenuItem(testCase.shortcutId, testCase.menuItemIndex)
+					return
+				}
+				err = testLaunchee.runMenuItem(shortcut, shortcut.MenuItems[testCase.menuItemIndex])
+			}
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("runMenuItem() = %v, want error %t", err, testCase.wantErr)
+			}
+			testLaunchee.RunMenuItem(testCase.shortcutId, testCase.menuItemIndex)
+		})
+	}
+}
+
+func TestToCommandLine(t *testing.T) {
+	testCases := map[string]struct {
+		command     string
+		commandArgs []string
+		want        string
+	}{
+		"only command":   {"echo", nil, "echo"},
+		"simple args":    {"echo", []string{"-n", "test"}, "echo -n test"},
+		"args spaces":    {"echo", []string{"foo bar"}, "echo 'foo bar'"},
+		"args quotes":    {"echo", []string{"it's"}, `echo 'it'\''s'`},
+		"empty arg":      {"echo", []string{""}, "echo ''"},
+		"command spaces": {"/opt/my app/run", nil, "'/opt/my app/run'"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := toCommandLine(testCase.command, testCase.commandArgs)
+			if got != testCase.want {
+				t.Errorf("toCommandLine() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestHideWindow(t *testing.T) {
+	windowImpl = stub.WindowStub{}
+	setWindowHidden(false)
+	NewLaunchee().HideWindow()
+	if !isWindowHidden() {
+		t.Error("HideWindow() did not hide the window")
+	}
+}
+
+// usageRecorder records the names of the launched shortcuts.
+type usageRecorder struct {
+	names []string
+}
+
+func (u *usageRecorder) Record(name string, _ time.Time) error {
+	u.names = append(u.names, name)
+	return nil
+}
+
+func TestRecordLaunch(t *testing.T) {
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+	defer func() { usageRecorderImpl = stub.UsageStoreStub{} }()
+	lctx.LoggerImpl = stub.LoggerStub{}
+	lctx.MessageDialogImpl = stub.MessageDialogValidStub{}
+	openerImpl = stub.OpenerStub{}
+
+	recorder := &usageRecorder{}
+	usageRecorderImpl = recorder
+	testLaunchee := &Launchee{Config: &frontend.Config{Shortcuts: []*frontend.Shortcut{
+		{Id: 0, StableId: "Command", Name: "Command", Command: "echo"},
+		{Id: 1, StableId: "Invalid", Name: "Invalid", Command: "echoo"},
+		{Id: 2, StableId: "web", Name: "Url", Url: "https://example.com"},
+	}}}
+	for id := range 3 {
+		testLaunchee.RunShortcut(id)
+	}
+	if diff := cmp.Diff([]string{"Command", "web"}, recorder.names); diff != "" {
+		t.Errorf("recorded launches = diff -want +got\n%s", diff)
+	}
+
+	// A store that cannot be written does not prevent launching
+	usageRecorderImpl = stub.UsageStoreCorruptedStub{}
+	testLaunchee.RunShortcut(0)
+}
@@ -0,0 +1,52 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jdheim/launchee/internal/config/yaml"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/pkg/errors"
+)
+
+// Migrate rewrites the custom config or, without one, the user config in the current version and returns the exit
+// code. The system config is left to its owner, who may run it with --config.
+func Migrate(writer io.Writer) int {
+	lctx.LoggerImpl = lctx.ConsoleLogger{}
+	configPath := customConfigPath
+	if configPath == "" {
+		configPath = yaml.ConfigPathImpl.GetUserConfigPath()
+	}
+	if configPath == "" {
+		printError("Invalid Config", errors.New("No user config found, set the config to migrate with --config"))
+		return ExitCodeInvalidConfig
+	}
+	version, backupPath, err := yaml.MigrateConfigFile(configPath)
+	if err != nil {
+		printError("Invalid Config", err)
+		return ExitCodeInvalidConfig
+	}
+	if backupPath == "" {
+		_, _ = fmt.Fprintf(writer, "%s is already of Version %d\n", configPath, version)
+	} else {
+		_, _ = fmt.Fprintf(writer, "Migrated %s from Version %d to %d, the original is backed up to %s\n", configPath,
+			version, yaml.CurrentVersion(), backupPath)
+	}
+	return ExitCodeOk
+}
@@ -0,0 +1,103 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdheim/launchee/internal/config/yaml"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+func TestMigrate(t *testing.T) {
+	originalLoggerImpl := lctx.LoggerImpl
+	originalConfigPathImpl := yaml.ConfigPathImpl
+	defer func() {
+		lctx.LoggerImpl = originalLoggerImpl
+		yaml.ConfigPathImpl = originalConfigPathImpl
+		customConfigPath = ""
+	}()
+	testCases := map[string]struct {
+		config     string
+		want       int
+		wantBackup bool
+	}{
+		"unversioned": {"# Shortcuts\ntitle: \"Test\"\n", ExitCodeOk, true},
+		"version 1":   {"version: 1\ntitle: \"Test\"\n", ExitCodeOk, true},
+		"current":     {"version: 2\ntitle: \"Test\"\n", ExitCodeOk, false},
+		"newer":       {"version: 100\ntitle: \"Test\"\n", ExitCodeInvalidConfig, false},
+		"invalid":     {"title: [", ExitCodeInvalidConfig, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			customConfigPath = filepath.Join(t.TempDir(), "launchee.yml")
+			if err := os.WriteFile(customConfigPath, []byte(testCase.config), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			var output bytes.Buffer
+			if got := Migrate(&output); got != testCase.want {
+				t.Errorf("Migrate() = %d, want %d", got, testCase.want)
+			}
+			backup, err := os.ReadFile(customConfigPath + ".bak")
+			if gotBackup := err == nil; gotBackup != testCase.wantBackup {
+				t.Fatalf("Migrate() backed up = %t, want %t", gotBackup, testCase.wantBackup)
+			}
+			if testCase.wantBackup && string(backup) != testCase.config {
+				t.Errorf("Migrate() backed up %q, want %q", backup, testCase.config)
+			}
+		})
+	}
+
+	customConfigPath = ""
+	yaml.ConfigPathImpl = stub.ConfigPathNotExistsStub{}
+	if got := Migrate(&bytes.Buffer{}); got != ExitCodeInvalidConfig {
+		t.Errorf("Migrate() without a config = %d, want %d", got, ExitCodeInvalidConfig)
+	}
+}
+
+func TestMigrateKeepsComments(t *testing.T) {
+	originalLoggerImpl := lctx.LoggerImpl
+	defer func() {
+		lctx.LoggerImpl = originalLoggerImpl
+		customConfigPath = ""
+	}()
+	customConfigPath = filepath.Join(t.TempDir(), "launchee.yml")
+	if err := os.WriteFile(customConfigPath, []byte("# My dock\ntitle: \"Test\" # Shown in the title bar\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	if got := Migrate(&output); got != ExitCodeOk {
+		t.Fatalf("Migrate() = %d, want %d", got, ExitCodeOk)
+	}
+	migrated, err := os.ReadFile(customConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# My dock\nversion: 2\ntitle: \"Test\" # Shown in the title bar\n"
+	if string(migrated) != want {
+		t.Errorf("Migrate() wrote %q, want %q", migrated, want)
+	}
+	if !strings.Contains(output.String(), "backed up to "+customConfigPath+".bak") {
+		t.Errorf("Migrate() printed %q, want the backup path", output.String())
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/pkg/errors"
+)
+
+var portWaitTimeout = 30 * time.Second
+
+var portPollInterval = 250 * time.Millisecond
+
+// startedAction tracks an action that has been started, so that the next stage can wait for it to exit.
+type startedAction struct {
+	number int
+	done   chan struct{}
+	err    error
+}
+
+// wait blocks until the action exits or the context is cancelled.
+func (sa *startedAction) wait(ctx context.Context) error {
+	select {
+	case <-sa.done:
+		if sa.err != nil {
+			return errors.WithMessagef(sa.err, "Action %d exited with an error", sa.number)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// runActions runs the actions stage by stage. A stage is an action followed by all the actions marked as parallel,
+// which start together. The first failure cancels all the actions that have not been started yet.
+func runActions(ctx context.Context, actions []*frontend.Action) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var previousStage []*startedAction
+	number := 1
+	for _, stage := range toStages(actions) {
+		startedStage, err := runStage(ctx, cancel, number, stage, previousStage)
+		if err != nil {
+			return err
+		}
+		number += len(stage)
+		previousStage = startedStage
+	}
+	return nil
+}
+
+// toStages groups the actions into stages.
+func toStages(actions []*frontend.Action) [][]*frontend.Action {
+	var stages [][]*frontend.Action
+	for _, action := range actions {
+		if action.Parallel && len(stages) != 0 {
+			stages[len(stages)-1] = append(stages[len(stages)-1], action)
+		} else {
+			stages = append(stages, []*frontend.Action{action})
+		}
+	}
+	return stages
+}
+
+// runStage starts all the actions of a stage concurrently and returns them once all of them have been started.
+func runStage(ctx context.Context, cancel context.CancelFunc, firstNumber int, stage []*frontend.Action,
+	previousStage []*startedAction) ([]*startedAction, error) {
+	startedStage := make([]*startedAction, len(stage))
+	var firstErr error
+	var errLock sync.Mutex
+	var wg sync.WaitGroup
+	for i, action := range stage {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started, err := runAction(ctx, firstNumber+i, action, previousStage)
+			if err != nil {
+				errLock.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				errLock.Unlock()
+				return
+			}
+			startedStage[i] = started
+		}()
+	}
+	wg.Wait()
+	return startedStage, firstErr
+}
+
+// runAction waits for the action's conditions to be met and starts it.
+func runAction(ctx context.Context, number int, action *frontend.Action, previousStage []*startedAction) (*startedAction, error) {
+	if err := waitForAction(ctx, action.WaitFor, previousStage); err != nil {
+		return nil, errors.WithMessagef(err, "Action %d was cancelled", number)
+	}
+	started := &startedAction{number: number, done: make(chan struct{})}
+	if action.Url != "" || action.Path != "" {
+		var err error
+		if action.Path != "" {
+			err = openerImpl.OpenPath(action.Path)
+		} else {
+			err = openerImpl.OpenURL(action.Url)
+		}
+		if err != nil {
+			return nil, errors.WithMessagef(err, "Action %d could not be opened", number)
+		}
+		close(started.done)
+		return started, nil
+	}
+	cmd, err := startCommand(action.Command, action.CommandArgs)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "Action %d could not be started", number)
+	}
+	go func() {
+		defer close(started.done)
+		if started.err = cmd.Wait(); started.err != nil {
+			lctx.LogErrorf("Error occurred when finishing an action %v: %v", cmd, started.err)
+		}
+	}()
+	return started, nil
+}
+
+// waitForAction blocks until all the conditions of waitFor are met or the context is cancelled.
+func waitForAction(ctx context.Context, waitFor *frontend.WaitFor, previousStage []*startedAction) error {
+	if waitFor == nil {
+		return ctx.Err()
+	}
+	if waitFor.Exit {
+		for _, previous := range previousStage {
+			if err := previous.wait(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	if waitFor.Delay > 0 {
+		if err := sleep(ctx, waitFor.Delay); err != nil {
+			return err
+		}
+	}
+	if waitFor.Port != "" {
+		if err := waitForPort(ctx, waitFor.Port); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// sleep blocks for the duration or until the context is cancelled.
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitForPort blocks until a TCP connection to the address succeeds, the timeout elapses or the context is cancelled.
+func waitForPort(ctx context.Context, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, portWaitTimeout)
+	defer cancel()
+	dialer := net.Dialer{}
+	for {
+		if conn, err := dialer.DialContext(ctx, "tcp", address); err == nil {
+			_ = conn.Close()
+			return nil
+		}
+		if err := sleep(ctx, portPollInterval); err != nil {
+			return errors.WithMessagef(err, "Port %s is not ready", address)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+func TestToStages(t *testing.T) {
+	testCases := map[string]struct {
+		in   []*frontend.Action
+		want []int
+	}{
+		"nil":                {nil, nil},
+		"one":                {[]*frontend.Action{{}}, []int{1}},
+		"sequential":         {[]*frontend.Action{{}, {}, {}}, []int{1, 1, 1}},
+		"parallel":           {[]*frontend.Action{{}, {Parallel: true}, {Parallel: true}}, []int{3}},
+		"mixed":              {[]*frontend.Action{{}, {Parallel: true}, {}, {}, {Parallel: true}}, []int{2, 1, 2}},
+		"first parallel":     {[]*frontend.Action{{Parallel: true}, {}}, []int{1, 1}},
+		"parallel after one": {[]*frontend.Action{{}, {}, {Parallel: true}}, []int{1, 2}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := toStages(testCase.in)
+			if len(got) != len(testCase.want) {
+				t.Fatalf("toStages() = %d stages, want %d", len(got), len(testCase.want))
+			}
+			for i, stage := range got {
+				if len(stage) != testCase.want[i] {
+					t.Errorf("toStages()[%d] = %d actions, want %d", i, len(stage), testCase.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRunActions(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() = %v", err)
+	}
+	defer func() { _ = listener.Close() }()
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() = %v", err)
+	}
+	_ = closedListener.Close()
+
+	originalPortWaitTimeout := portWaitTimeout
+	defer func() { portWaitTimeout = originalPortWaitTimeout }()
+	portWaitTimeout = 300 * time.Millisecond
+
+	testCases := map[string]struct {
+		in      []*frontend.Action
+		wantErr bool
+	}{
+		"nil":              {nil, false},
+		"command":          {[]*frontend.Action{{Command: "echo", CommandArgs: []string{"test"}}}, false},
+		"url":              {[]*frontend.Action{{Url: "https://example.com"}}, false},
+		"path":             {[]*frontend.Action{{Path: "."}}, false},
+		"invalid command":  {[]*frontend.Action{{Command: "echoo"}}, true},
+		"invalid parallel": {[]*frontend.Action{{Command: "echo"}, {Command: "echoo", Parallel: true}}, true},
+		"sequential": {[]*frontend.Action{
+			{Command: "echo", CommandArgs: []string{"1"}},
+			{Url: "https://example.com"},
+			{Command: "echo", CommandArgs: []string{"3"}},
+		}, false},
+		"parallel": {[]*frontend.Action{
+			{Command: "echo", CommandArgs: []string{"1"}},
+			{Command: "echo", CommandArgs: []string{"2"}, Parallel: true},
+		}, false},
+		"wait for exit": {[]*frontend.Action{
+			{Command: "true"},
+			{Command: "echo", WaitFor: &frontend.WaitFor{Exit: true}},
+		}, false},
+		"wait for exit with error": {[]*frontend.Action{
+			{Command: "false"},
+			{Command: "echo", WaitFor: &frontend.WaitFor{Exit: true}},
+		}, true},
+		"wait for delay": {[]*frontend.Action{
+			{Command: "echo", WaitFor: &frontend.WaitFor{Delay: 10 * time.Millisecond}},
+		}, false},
+		"wait for port": {[]*frontend.Action{
+			{Command: "echo", WaitFor: &frontend.WaitFor{Port: listener.Addr().String()}},
+		}, false},
+		"wait for port timeout": {[]*frontend.Action{
+			{Command: "echo", WaitFor: &frontend.WaitFor{Port: closedListener.Addr().String()}},
+		}, true},
+	}
+
+	lctx.LoggerImpl = stub.LoggerStub{}
+	openerImpl = stub.OpenerStub{}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := runActions(context.Background(), testCase.in)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("runActions() = %v, want error %t", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunActionsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := runActions(ctx, []*frontend.Action{{Command: "echo", WaitFor: &frontend.WaitFor{Delay: time.Hour}}})
+	if err == nil {
+		t.Error("runActions() = error expected")
+	}
+}
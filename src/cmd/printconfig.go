@@ -0,0 +1,101 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/config/yaml"
+	"github.com/jdheim/launchee/internal/lctx"
+)
+
+// PrintConfig prints the shortcuts of the config loaded exactly as when the dock starts, followed by the ones skipped
+// on this machine by their when clause and the ones dropped by the lenient validation, and returns the exit code.
+func PrintConfig(writer io.Writer) int {
+	lctx.LoggerImpl = lctx.ConsoleLogger{}
+	config, err := loadConfig()
+	if err != nil {
+		printError("Invalid Config", err)
+		return ExitCodeInvalidConfig
+	}
+	printConfig(writer, config)
+	return ExitCodeOk
+}
+
+// PrintSchema prints the JSON Schema of the config, which editors use to complete and check launchee.yml, and returns
+// the exit code.
+func PrintSchema(writer io.Writer) int {
+	schema, err := yaml.Schema()
+	if err != nil {
+		printError("Could not generate the schema", err)
+		return ExitCodeInvalidConfig
+	}
+	_, _ = fmt.Fprintln(writer, string(schema))
+	return ExitCodeOk
+}
+
+func printConfig(writer io.Writer, config *frontend.Config) {
+	_, _ = fmt.Fprintf(writer, "Title: %s\n", config.UI.Nav.Title)
+	if config.Profile != "" {
+		_, _ = fmt.Fprintf(writer, "Profile: %s\n", config.Profile)
+	}
+	_, _ = fmt.Fprintln(writer, "Shortcuts:")
+	for _, shortcut := range config.Shortcuts {
+		name := shortcut.Name
+		if shortcut.StableId != "" && shortcut.StableId != shortcut.Name {
+			name = fmt.Sprintf("%s (%s)", shortcut.Name, shortcut.StableId)
+		}
+		_, _ = fmt.Fprintf(writer, "  %d  %s: %s\n", shortcut.Id, name, describeTarget(shortcut))
+	}
+	if len(config.SkippedShortcuts) != 0 {
+		_, _ = fmt.Fprintln(writer, "Skipped:")
+		for _, skipped := range config.SkippedShortcuts {
+			printDroppedShortcut(writer, skipped.Name, skipped.Profile, skipped.Reason)
+		}
+	}
+	if len(config.InvalidShortcuts) != 0 {
+		_, _ = fmt.Fprintln(writer, "Invalid:")
+		for _, invalid := range config.InvalidShortcuts {
+			printDroppedShortcut(writer, invalid.Name, invalid.Profile, invalid.Problem)
+		}
+	}
+}
+
+func printDroppedShortcut(writer io.Writer, name string, profile string, reason string) {
+	if profile != "" {
+		_, _ = fmt.Fprintf(writer, "  %s (%s Profile): %s\n", name, profile, reason)
+	} else {
+		_, _ = fmt.Fprintf(writer, "  %s: %s\n", name, reason)
+	}
+}
+
+// describeTarget returns what the shortcut launches on click.
+func describeTarget(shortcut *frontend.Shortcut) string {
+	switch {
+	case len(shortcut.Actions) != 0:
+		return fmt.Sprintf("%d Actions", len(shortcut.Actions))
+	case shortcut.Command != "":
+		return strings.Join(append([]string{shortcut.Command}, shortcut.CommandArgs...), " ")
+	case shortcut.Url != "":
+		return shortcut.Url
+	default:
+		return shortcut.Path
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+func TestPrintConfig(t *testing.T) {
+	useTestProfileConfig(t)
+	var got bytes.Buffer
+	if exitCode := PrintConfig(&got); exitCode != ExitCodeOk {
+		t.Errorf("PrintConfig() = %d, want %d", exitCode, ExitCodeOk)
+	}
+	want := "Title: Base\nShortcuts:\n  0  Terminal: echo\n"
+	if got.String() != want {
+		t.Errorf("PrintConfig() printed %q, want %q", got.String(), want)
+	}
+
+	customConfigPath = stub.ConfigPathInvalidStub{}.GetSystemConfigPath()
+	if exitCode := PrintConfig(&got); exitCode != ExitCodeInvalidConfig {
+		t.Errorf("PrintConfig() = %d, want %d", exitCode, ExitCodeInvalidConfig)
+	}
+}
+
+func TestPrintSchema(t *testing.T) {
+	var got bytes.Buffer
+	if exitCode := PrintSchema(&got); exitCode != ExitCodeOk {
+		t.Errorf("PrintSchema() = %d, want %d", exitCode, ExitCodeOk)
+	}
+	if !json.Valid(got.Bytes()) {
+		t.Errorf("PrintSchema() printed invalid JSON:\n%s", got.String())
+	}
+}
+
+func TestPrintConfigDropped(t *testing.T) {
+	config := frontend.NewConfig(4)
+	config.Profile = "ops"
+	config.Shortcuts = []*frontend.Shortcut{
+		{Id: 0, StableId: "Terminal", Name: "Terminal", Command: "kitty", CommandArgs: []string{"--single-instance"}},
+		{Id: 1, StableId: "web", Name: "Firefox", Url: "https://example.com"},
+		{Id: 2, StableId: "Notes", Name: "Notes", Path: "/home/user/notes.txt"},
+		{Id: 3, StableId: "Dev", Name: "Dev", Actions: []*frontend.Action{{Command: "echo"}, {Command: "echo"}}},
+	}
+	config.SkippedShortcuts = []*frontend.SkippedShortcut{
+		{Name: "Steam", Reason: "OS \"linux\" is not \"windows\""},
+		{Name: "Status", Profile: "ops", Reason: "command \"status\" does not exist"},
+	}
+	config.InvalidShortcuts = []*frontend.InvalidShortcut{
+		{Name: "Slack", Problem: "Icon of \"Slack\" Shortcut must be set"},
+	}
+	want := `Title: Launchee
+Profile: ops
+Shortcuts:
+  0  Terminal: kitty --single-instance
+  1  Firefox (web): https://example.com
+  2  Notes: /home/user/notes.txt
+  3  Dev: 2 Actions
+Skipped:
+  Steam: OS "linux" is not "windows"
+  Status (ops Profile): command "status" does not exist
+Invalid:
+  Slack: Icon of "Slack" Shortcut must be set
+`
+
+	var got bytes.Buffer
+	printConfig(&got, config)
+	if got.String() != want {
+		t.Errorf("printConfig() printed\n%s\nwant\n%s", got.String(), want)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	goruntime "runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/pkg/errors"
+)
+
+// processRegistry keeps track of the processes started by each shortcut, so they can be stopped later on. The
+// shortcuts are told apart by their stable ids, which stay the same across reloads of the config.
+type processRegistry struct {
+	lock      sync.Mutex
+	processes map[string][]*exec.Cmd
+}
+
+var processes = newProcessRegistry()
+
+type runningProcess struct {
+	name string
+	pid  int
+}
+
+func newProcessRegistry() *processRegistry {
+	return &processRegistry{
+		processes: make(map[string][]*exec.Cmd),
+	}
+}
+
+// add registers a process started by the shortcut.
+func (r *processRegistry) add(name string, cmd *exec.Cmd) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.processes[name] = append(r.processes[name], cmd)
+}
+
+// remove unregisters a process started by the shortcut.
+func (r *processRegistry) remove(name string, cmd *exec.Cmd) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	running := r.processes[name]
+	for i, runningCmd := range running {
+		if runningCmd == cmd {
+			running = append(running[:i], running[i+1:]...)
+			break
+		}
+	}
+	if len(running) == 0 {
+		delete(r.processes, name)
+	} else {
+		r.processes[name] = running
+	}
+}
+
+// running returns the number of processes started by the shortcut that are still running.
+func (r *processRegistry) running(name string) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.processes[name])
+}
+
+// list returns the processes that are still running, ordered by the name of the shortcut that started them.
+func (r *processRegistry) list() []*runningProcess {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var list []*runningProcess
+	for name, running := range r.processes {
+		for _, cmd := range running {
+			list = append(list, &runningProcess{name: name, pid: cmd.Process.Pid})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].name != list[j].name {
+			return list[i].name < list[j].name
+		}
+		return list[i].pid < list[j].pid
+	})
+	return list
+}
+
+// stop kills all the processes started by the shortcut.
+func (r *processRegistry) stop(name string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	running := r.processes[name]
+	if len(running) == 0 {
+		return errors.Errorf("\"%s\" Shortcut has no running process", name)
+	}
+	for _, cmd := range running {
+		if err := cmd.Process.Kill(); err != nil {
+			return errors.WithMessagef(err, "Could not stop %v", cmd)
+		}
+	}
+	return nil
+}
+
+var logDir = func() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, "launchee", "logs")
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^\p{L}\p{N}._-]+`)
+
+// logPath returns the path of the file with the output of the processes of the shortcut with the stable id.
+func logPath(stableId string) string {
+	return filepath.Join(logDir(), unsafeFileNameChars.ReplaceAllString(stableId, "_")+".log")
+}
+
+// openLog opens the log file of the shortcut with the stable id for appending.
+func openLog(stableId string) (*os.File, error) {
+	path := logPath(stableId)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+}
+
+// startShortcutCommand starts the shortcut's command with its output copied into the shortcut's log file.
+func startShortcutCommand(shortcut *frontend.Shortcut) error {
+	logFile, err := openLog(shortcut.StableId)
+	if err != nil {
+		return errors.WithMessagef(err, "Could not open the log of \"%s\" Shortcut", shortcut.Name)
+	}
+	var cmd *exec.Cmd
+	if headless {
+		// The output goes to the log only, so the command does not depend on Launchee once it exits
+		cmd, err = startCommandWithOutput(shortcut.Command, shortcut.CommandArgs, logFile, logFile)
+	} else {
+		cmd, err = startCommandWithOutput(shortcut.Command, shortcut.CommandArgs,
+			io.MultiWriter(os.Stdout, logFile), io.MultiWriter(os.Stderr, logFile))
+	}
+	if err != nil {
+		_ = logFile.Close()
+		return err
+	}
+	processes.add(shortcut.StableId, cmd)
+	waitingCommands.Add(1)
+	go func() {
+		defer waitingCommands.Done()
+		defer func() { _ = logFile.Close() }()
+		defer processes.remove(shortcut.StableId, cmd)
+		if err := cmd.Wait(); err != nil {
+			lctx.LogErrorf("Error occurred when finishing a command %v: %v", cmd, err)
+		}
+	}()
+	return nil
+}
+
+var getGOOS = func() string {
+	return goruntime.GOOS
+}
+
+// isWebUrl reports whether the URL should be opened in the browser rather than with the desktop's default handler.
+func isWebUrl(url string) bool {
+	lowerUrl := strings.ToLower(url)
+	return strings.HasPrefix(lowerUrl, "http://") || strings.HasPrefix(lowerUrl, "https://")
+}
+
+// openCommand returns the command that opens the target with the desktop's default handler.
+func openCommand(target string) (string, []string) {
+	switch getGOOS() {
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", target}
+	case "darwin":
+		return "open", []string{target}
+	default:
+		return "xdg-open", []string{target}
+	}
+}
+
+// startOpenCommand opens the target with the desktop's default handler without waiting for it.
+func startOpenCommand(target string) error {
+	cmd, err := startCommand(openCommand(target))
+	if err != nil {
+		return errors.WithMessagef(err, "Could not open \"%s\"", target)
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			lctx.LogErrorf("Error occurred when opening %s: %v", target, err)
+		}
+	}()
+	return nil
+}
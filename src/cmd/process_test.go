@@ -0,0 +1,195 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProcessRegistry(t *testing.T) {
+	registry := newProcessRegistry()
+	if err := registry.stop("Test"); err == nil {
+		t.Error("stop() = error expected without running processes")
+	}
+	cmd, err := startCommand("sleep", []string{"10"})
+	if err != nil {
+		t.Fatalf("startCommand() = %v", err)
+	}
+	otherCmd, err := startCommand("sleep", []string{"10"})
+	if err != nil {
+		t.Fatalf("startCommand() = %v", err)
+	}
+	registry.add("Test", cmd)
+	registry.add("Test", otherCmd)
+	if got := registry.running("Test"); got != 2 {
+		t.Errorf("running() = %d, want 2", got)
+	}
+	if err := registry.stop("Test"); err != nil {
+		t.Errorf("stop() = %v", err)
+	}
+	if err := cmd.Wait(); err == nil {
+		t.Error("Wait() = error expected for a killed process")
+	}
+	_ = otherCmd.Wait()
+	registry.remove("Test", cmd)
+	if got := registry.running("Test"); got != 1 {
+		t.Errorf("running() = %d, want 1", got)
+	}
+	registry.remove("Test", otherCmd)
+	if got := registry.running("Test"); got != 0 {
+		t.Errorf("running() = %d, want 0", got)
+	}
+}
+
+func TestLogPath(t *testing.T) {
+	testCases := map[string]struct {
+		in   string
+		want string
+	}{
+		"simple":       {"Terminal", "Terminal.log"},
+		"with spaces":  {"Text Editor", "Text_Editor.log"},
+		"with slashes": {"../etc/passwd", ".._etc_passwd.log"},
+		"utf-8":        {"Przeglądarka 😎", "Przeglądarka_.log"},
+	}
+
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := logPath(testCase.in)
+			want := filepath.Join(testLogDir, testCase.want)
+			if got != want {
+				t.Errorf("logPath(%q) = %q, want %q", testCase.in, got, want)
+			}
+		})
+	}
+}
+
+func TestDefaultLogDir(t *testing.T) {
+	if got := logDir(); filepath.Base(got) != "logs" {
+		t.Errorf("logDir() = %q, want a logs dir", got)
+	}
+}
+
+func TestStartShortcutCommand(t *testing.T) {
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+	lctx.LoggerImpl = stub.LoggerStub{}
+
+	if err := startShortcutCommand(&frontend.Shortcut{StableId: "Test", Name: "Test", Command: "echo", CommandArgs: []string{"logged"}}); err != nil {
+		t.Fatalf("startShortcutCommand() = %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got, err := os.ReadFile(logPath("Test")); err != nil || string(got) != "logged\n" {
+		t.Errorf("startShortcutCommand() log = %q, %v, want %q", got, err, "logged\n")
+	}
+	if err := startShortcutCommand(&frontend.Shortcut{StableId: "Test", Name: "Test", Command: "echoo"}); err == nil {
+		t.Error("startShortcutCommand() = error expected")
+	}
+
+	logDir = func() string { return "/dev/null" }
+	if err := startShortcutCommand(&frontend.Shortcut{StableId: "Test", Name: "Test", Command: "echo"}); err == nil {
+		t.Error("startShortcutCommand() = error expected")
+	}
+}
+
+func TestIsWebUrl(t *testing.T) {
+	testCases := map[string]struct {
+		in   string
+		want bool
+	}{
+		"empty":     {"", false},
+		"http":      {"http://example.com", true},
+		"https":     {"https://example.com", true},
+		"uppercase": {"HTTPS://example.com", true},
+		"ssh":       {"ssh://dev@example.com", false},
+		"mailto":    {"mailto:dev@example.com", false},
+		"file":      {"file:///tmp", false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := isWebUrl(testCase.in); got != testCase.want {
+				t.Errorf("isWebUrl(%q) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestOpenCommand(t *testing.T) {
+	originalGetGOOS := getGOOS
+	defer func() { getGOOS = originalGetGOOS }()
+
+	testCases := map[string]struct {
+		goos        string
+		wantCommand string
+		wantArgs    []string
+	}{
+		"linux":   {"linux", "xdg-open", []string{"/tmp"}},
+		"freebsd": {"freebsd", "xdg-open", []string{"/tmp"}},
+		"darwin":  {"darwin", "open", []string{"/tmp"}},
+		"windows": {"windows", "rundll32", []string{"url.dll,FileProtocolHandler", "/tmp"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			getGOOS = func() string { return testCase.goos }
+			gotCommand, gotArgs := openCommand("/tmp")
+			if gotCommand != testCase.wantCommand {
+				t.Errorf("openCommand() command = %q, want %q", gotCommand, testCase.wantCommand)
+			}
+			if diff := cmp.Diff(testCase.wantArgs, gotArgs); diff != "" {
+				t.Errorf("openCommand() args mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStartOpenCommand(t *testing.T) {
+	originalGetGOOS := getGOOS
+	defer func() { getGOOS = originalGetGOOS }()
+	getGOOS = func() string { return "linux" }
+
+	testPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(testPath, "xdg-open"), []byte("#!/bin/sh\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", testPath)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	if err := startOpenCommand(testPath); err != nil {
+		t.Errorf("startOpenCommand() = %v, want nil", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	t.Setenv("PATH", t.TempDir())
+	if err := startOpenCommand(testPath); err == nil {
+		t.Error("startOpenCommand() = error expected")
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "github.com/jdheim/launchee/internal/lctx"
+
+var profile string
+
+// SetProfile sets the profile of shortcuts that the config is loaded with. No name loads the shortcuts without any.
+func SetProfile(name string) {
+	configLock.Lock()
+	defer configLock.Unlock()
+	profile = name
+}
+
+func currentProfile() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return profile
+}
+
+// SwitchProfile replaces the shortcuts with the ones of the profile and resizes the dock to fit them.
+func (l *Launchee) SwitchProfile(name string) {
+	if err := l.switchProfile(name); err != nil {
+		lctx.NewErrorMessageDialog("Error occurred when switching the profile", err)
+	}
+}
+
+// switchProfile reloads the config with the profile and keeps the current one when it cannot be loaded.
+func (l *Launchee) switchProfile(name string) error {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	previousProfile := currentProfile()
+	SetProfile(name)
+	if err := l.reloadConfig(); err != nil {
+		SetProfile(previousProfile)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/ipc"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+const profileConfig = `title: "Base"
+shortcuts:
+  - name: "Terminal"
+    icon: "%[1]s"
+    command: "echo"
+profiles:
+  - name: "ops"
+    title: "Operations"
+    shortcuts:
+      - name: "Status"
+        icon: "%[1]s"
+        url: "https://example.com"
+`
+
+// useTestProfileConfig points the custom config path to a config with the "ops" profile.
+func useTestProfileConfig(t *testing.T) {
+	icon, err := filepath.Abs(filepath.Join("..", "internal", "test", "stub", "stub_config", "icons", "default128.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "launchee.yml")
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(profileConfig, icon)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalCustomConfigPath, originalProfile := customConfigPath, profile
+	t.Cleanup(func() { customConfigPath, profile = originalCustomConfigPath, originalProfile })
+	customConfigPath = path
+}
+
+func TestSetProfile(t *testing.T) {
+	defer SetProfile("")
+	SetProfile("ops")
+	if profile != "ops" {
+		t.Errorf("SetProfile() = %q, want ops", profile)
+	}
+}
+
+func TestSwitchProfile(t *testing.T) {
+	useTestProfileConfig(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	lctx.MessageDialogImpl = stub.MessageDialogValidStub{}
+	windowImpl = stub.WindowStub{}
+	eventsImpl = stub.EventsStub{}
+	testCases := []struct {
+		name          string
+		wantProfile   string
+		wantTitle     string
+		wantShortcuts int
+	}{
+		{"ops", "ops", "Operations", 2},
+		{"meetings", "ops", "Operations", 2},
+		{"", "", "Base", 1},
+	}
+
+	testLaunchee := &Launchee{Config: frontend.NewConfig(0)}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testLaunchee.SwitchProfile(testCase.name)
+			got := testLaunchee.Config
+			if profile != testCase.wantProfile || got.Profile != testCase.wantProfile ||
+				got.UI.Nav.Title != testCase.wantTitle || len(got.Shortcuts) != testCase.wantShortcuts {
+				t.Errorf("SwitchProfile(%q) = profile %q titled %q with %d shortcuts, want %q titled %q with %d",
+					testCase.name, got.Profile, got.UI.Nav.Title, len(got.Shortcuts), testCase.wantProfile,
+					testCase.wantTitle, testCase.wantShortcuts)
+			}
+		})
+	}
+}
+
+// TestSwitchProfileConcurrently switches the profile from the goroutines of the IPC, D-Bus and control requests while
+// the UI reads the config, which go test -race checks.
+func TestSwitchProfileConcurrently(t *testing.T) {
+	useTestProfileConfig(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	windowImpl = stub.WindowStub{}
+	eventsImpl = stub.EventsStub{}
+
+	testLaunchee := &Launchee{Config: frontend.NewConfig(0)}
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := testLaunchee.switchProfile([]string{"ops", ""}[i%2]); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if testLaunchee.GetConfig() == nil {
+				t.Error("GetConfig() = nil")
+			}
+		}()
+	}
+	wg.Wait()
+	if got := testLaunchee.GetConfig().Profile; got != currentProfile() {
+		t.Errorf("GetConfig() profile = %q, want the current %q", got, currentProfile())
+	}
+}
+
+func TestSwitchRequestedProfile(t *testing.T) {
+	useTestProfileConfig(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	windowImpl = stub.WindowStub{}
+	eventsImpl = stub.EventsStub{}
+	testCases := map[string]struct {
+		in          *ipc.Request
+		wantProfile string
+		wantErr     bool
+	}{
+		"no profile": {&ipc.Request{Command: ipc.CommandShow}, "", false},
+		"show":       {&ipc.Request{Command: ipc.CommandShow, Profile: "ops"}, "ops", false},
+		"toggle":     {&ipc.Request{Command: ipc.CommandToggle, Profile: "ops"}, "ops", false},
+		"undefined":  {&ipc.Request{Command: ipc.CommandShow, Profile: "meetings"}, "", true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			profile = ""
+			err := (&Launchee{Config: frontend.NewConfig(0)}).handleRequest(testCase.in)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("handleRequest() = %v, want error %t", err, testCase.wantErr)
+			}
+			if profile != testCase.wantProfile {
+				t.Errorf("handleRequest() switched to %q, want %q", profile, testCase.wantProfile)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/jdheim/launchee/build"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/tray"
+)
+
+// syncTray shows, refreshes or removes the tray icon, so it matches the config.
+func (l *Launchee) syncTray() {
+	config := l.config()
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+	enabled := config != nil && config.Tray != nil && config.Tray.Enabled
+	switch {
+	case enabled && instance.tray == nil:
+		icon, err := tray.Start(build.GetAppIconBytes(), config.UI.Nav.Title, toggleWindow, l.trayMenu())
+		if err != nil {
+			lctx.LogErrorf("Error occurred when showing the tray icon: %v", err)
+			return
+		}
+		instance.tray = icon
+	case enabled:
+		instance.tray.SetMenu(l.trayMenu())
+	case instance.tray != nil:
+		closeTray(instance.tray)
+		instance.tray = nil
+	}
+}
+
+func (l *Launchee) stopTray() {
+	instance.lock.Lock()
+	icon := instance.tray
+	instance.tray = nil
+	instance.lock.Unlock()
+	if icon != nil {
+		closeTray(icon)
+	}
+}
+
+func closeTray(icon *tray.Tray) {
+	if err := icon.Close(); err != nil {
+		lctx.LogErrorf("Error occurred when removing the tray icon: %v", err)
+	}
+}
+
+// trayMenu lists the shortcuts followed by the items that control the dock.
+func (l *Launchee) trayMenu() []*tray.MenuItem {
+	config := l.config()
+	items := make([]*tray.MenuItem, 0, len(config.Shortcuts)+7)
+	for _, shortcut := range config.Shortcuts {
+		items = append(items, &tray.MenuItem{Label: shortcut.Name, OnClick: func() {
+			if err := l.launchShortcut(shortcut); err != nil {
+				lctx.NewErrorMessageDialog("Error occurred when running a shortcut", err)
+			}
+		}})
+	}
+	if len(items) != 0 {
+		items = append(items, tray.Separator())
+	}
+	return append(items,
+		&tray.MenuItem{Label: "Show", OnClick: func() { setWindowHidden(false) }},
+		&tray.MenuItem{Label: "Hide", OnClick: func() { setWindowHidden(true) }},
+		&tray.MenuItem{Label: "Reload config", OnClick: func() {
+			if err := l.reload(); err != nil {
+				lctx.NewErrorMessageDialog("Error occurred when reloading the config", err)
+			}
+		}},
+		tray.Separator(),
+		&tray.MenuItem{Label: "Quit", OnClick: windowImpl.Quit},
+	)
+}
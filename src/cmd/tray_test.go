@@ -0,0 +1,120 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/bus"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+func TestSyncTray(t *testing.T) {
+	bus.StartSessionBus(t)
+	watcher := bus.ServeStatusNotifierWatcher(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+
+	testLaunchee := &Launchee{Config: frontend.NewConfig(0)}
+	testLaunchee.syncTray()
+	if instance.tray != nil {
+		t.Error("syncTray() showed the disabled tray icon")
+	}
+
+	testLaunchee.Config.Tray = &frontend.Tray{Enabled: true}
+	testLaunchee.syncTray()
+	if instance.tray == nil {
+		t.Fatal("syncTray() did not show the tray icon")
+	}
+	if len(watcher.Items()) != 1 {
+		t.Errorf("registered items = %v, want one", watcher.Items())
+	}
+
+	// A reloaded config refreshes the menu
+	testLaunchee.syncTray()
+	if len(watcher.Items()) != 1 {
+		t.Errorf("registered items = %v, want one", watcher.Items())
+	}
+
+	testLaunchee.Config.Tray.Enabled = false
+	testLaunchee.syncTray()
+	if instance.tray != nil {
+		t.Error("syncTray() did not remove the tray icon")
+	}
+	testLaunchee.stopTray()
+}
+
+func TestTrayMenu(t *testing.T) {
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+	lctx.LoggerImpl = stub.LoggerStub{}
+	windowImpl = stub.WindowStub{}
+
+	testCases := map[string]struct {
+		in   []*frontend.Shortcut
+		want []string
+	}{
+		"no shortcuts": {nil, []string{"Show", "Hide", "Reload config", "", "Quit"}},
+		"shortcuts": {[]*frontend.Shortcut{
+			{Id: 0, StableId: "Terminal", Name: "Terminal", Command: "echo"},
+			{Id: 1, StableId: "Browser", Name: "Browser", Url: "https://example.com"},
+		}, []string{"Terminal", "Browser", "", "Show", "Hide", "Reload config", "", "Quit"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testLaunchee := &Launchee{Config: &frontend.Config{Shortcuts: testCase.in}}
+			var got []string
+			for _, item := range testLaunchee.trayMenu() {
+				got = append(got, item.Label)
+				if item.Separator != (item.Label == "") {
+					t.Errorf("trayMenu() item %q separator = %t", item.Label, item.Separator)
+				}
+			}
+			if diff := cmp.Diff(testCase.want, got); diff != "" {
+				t.Errorf("trayMenu() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTrayMenuClick(t *testing.T) {
+	originalLogDir := logDir
+	defer func() { logDir = originalLogDir }()
+	testLogDir := t.TempDir()
+	logDir = func() string { return testLogDir }
+	lctx.LoggerImpl = stub.LoggerStub{}
+	windowImpl = stub.WindowStub{}
+
+	testLaunchee := &Launchee{Config: &frontend.Config{Shortcuts: []*frontend.Shortcut{
+		{Id: 0, StableId: "Terminal", Name: "Terminal", Command: "echo", CommandArgs: []string{"test"}},
+	}}}
+	items := testLaunchee.trayMenu()
+	items[0].OnClick()
+	items[3].OnClick()
+	if !isWindowHidden() {
+		t.Error("Hide did not hide the window")
+	}
+	items[2].OnClick()
+	if isWindowHidden() {
+		t.Error("Show did not show the window")
+	}
+}
@@ -15,8 +15,9 @@
  */
 
 import {useEffect, useState} from "react";
-import {GetConfig} from "../wailsjs/go/cmd/Launchee";
-import {WindowSetPosition, WindowShow} from "../wailsjs/runtime";
+import {GetConfig, LeaveWindow, RevealWindow} from "../wailsjs/go/cmd/Launchee";
+import {EventsOn, WindowSetPosition, WindowShow} from "../wailsjs/runtime";
+import {RecentRow} from "@/components/content/RecentRow.tsx";
 import {ShortcutGrid} from "@/components/content/ShortcutGrid.tsx";
 import {TitleBar} from "@/components/nav/TitleBar.tsx";
 import {frontend} from "../wailsjs/go/models";
@@ -25,6 +26,8 @@ function Launchee() {
     const [config, setConfig] = useState<frontend.Config | null>(null);
     const shortcuts = config?.Shortcuts ?? [];
     const ui = config?.UI ?? null;
+    const autoHide = config?.AutoHide?.Enabled ?? false;
+    const recent = config?.Recent ?? null;
 
     useEffect(() => {
         GetConfig().then(config => {
@@ -34,13 +37,31 @@ function Launchee() {
                 WindowShow();
             }
         });
+        return EventsOn("configReloaded", () => {
+            GetConfig().then(config => {
+                if (config.Valid) {
+                    setConfig(config)
+                }
+            });
+        });
     }, []);
 
     return (
-        <div className="grid grid-rows-[auto_1fr] h-screen w-screen bg-gradient-to-b from-[#48494C] to-[#2F3032] border-x-1 border-b-1 border-[#1e1f22] cursor-default select-none">
-            <TitleBar nav={ui?.Nav ?? null}/>
+        <div className="grid grid-rows-[auto_1fr_auto] h-screen w-screen bg-gradient-to-b from-[#48494C] to-[#2F3032] border-x-1 border-b-1 border-[#1e1f22] cursor-default select-none"
+             onMouseEnter={autoHide ? () => RevealWindow() : undefined}
+             onMouseLeave={autoHide ? () => LeaveWindow() : undefined}>
+            <TitleBar nav={ui?.Nav ?? null}
+                      hideToTray={config?.Tray?.Enabled ?? false}
+                      profiles={config?.Profiles ?? []}
+                      profile={config?.Profile ?? ""}
+                      invalidShortcuts={config?.InvalidShortcuts ?? []}/>
             <ShortcutGrid content={ui?.Content ?? null}
                           shortcuts={shortcuts}/>
+            {recent && ui?.Recent && (
+                <RecentRow content={ui.Recent}
+                           recent={recent}
+                           shortcuts={shortcuts}/>
+            )}
         </div>
     )
 }
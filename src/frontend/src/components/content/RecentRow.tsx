@@ -0,0 +1,69 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import {useEffect, useState} from "react";
+import {File} from "lucide-react";
+import {Tooltip, TooltipContent, TooltipProvider, TooltipTrigger} from "@/components/ui/tooltip.tsx";
+import {GetRecentItems, OpenRecentFile, RunShortcut} from "../../../wailsjs/go/cmd/Launchee";
+import {frontend} from "../../../wailsjs/go/models.ts";
+
+export function RecentRow({content, recent, shortcuts}: Readonly<{
+    content: frontend.Content,
+    recent: frontend.Recent,
+    shortcuts: frontend.Shortcut[]
+}>) {
+    const iconColumnsClass = `grid-cols-${content.IconColumns}`;
+    const iconSize = content.IconSize;
+    const marginClass = `mx-${content.Margin} mb-${content.Margin}`;
+    const gapClass = `gap-${content.Margin}`;
+
+    const [items, setItems] = useState<frontend.RecentItem[]>(recent.Items ?? []);
+
+    useEffect(() => {
+        setItems(recent.Items ?? []);
+        // Files are used and shortcuts launched while the dock is open, so the row is refreshed whenever it gets focus
+        const refresh = () => GetRecentItems().then(items => setItems(items ?? []));
+        window.addEventListener("focus", refresh);
+        return () => window.removeEventListener("focus", refresh);
+    }, [recent]);
+
+    return (
+        <div className={`grid ${iconColumnsClass} place-items-center-safe ${marginClass} ${gapClass}`}>
+            {items.map((item, index) => (
+                <TooltipProvider key={index} delayDuration={0}>
+                    <Tooltip>
+                        <TooltipTrigger asChild>
+                            <button onClick={() => item.Path ? OpenRecentFile(item.Path) : RunShortcut(item.ShortcutId)}
+                                    className={`active:scale-y-[0.85] transition-transform`}>
+                                {item.Path ? (
+                                    <File className="text-[#c4c6cc]" size={iconSize}/>
+                                ) : (
+                                    <img src={shortcuts.find(shortcut => shortcut.Id === item.ShortcutId)?.Icon?.Base64}
+                                         width={iconSize}
+                                         height={iconSize}
+                                         alt={item.Name}/>
+                                )}
+                            </button>
+                        </TooltipTrigger>
+                        <TooltipContent className="dark text-[11px] px-1.5 py-0.4 select-none" side="bottom" sideOffset={1}>
+                            {item.Name}
+                        </TooltipContent>
+                    </Tooltip>
+                </TooltipProvider>
+            ))}
+        </div>
+    );
+}
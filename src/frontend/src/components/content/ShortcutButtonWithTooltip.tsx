@@ -14,48 +14,53 @@
  * limitations under the License.
  */
 
-import {useState} from "react";
+import {Fragment, useState} from "react";
 import {Tooltip, TooltipContent, TooltipProvider, TooltipTrigger} from "@/components/ui/tooltip.tsx";
-import {RunCommand} from "../../../wailsjs/go/cmd/Launchee";
+import {ContextMenu, ContextMenuContent, ContextMenuItem, ContextMenuSeparator, ContextMenuTrigger} from "@/components/ui/context-menu.tsx";
+import {RunMenuItem, RunShortcut} from "../../../wailsjs/go/cmd/Launchee";
 import {frontend} from "../../../wailsjs/go/models.ts";
-import {BrowserOpenURL} from "../../../wailsjs/runtime";
 
 export function ShortcutButtonWithTooltip({shortcut, iconSize}: Readonly<{
     shortcut: frontend.Shortcut,
     iconSize: number
 }>) {
     const [open, setOpen] = useState(false);
+    const menuItems = shortcut?.MenuItems ?? [];
 
     return (
-        <TooltipProvider key={shortcut.Id} delayDuration={0}>
-            <Tooltip open={open} onOpenChange={setOpen}>
-                <TooltipTrigger asChild>
-                    {shortcut?.Command?.length > 0 ? (
-                        <button onClick={() => RunCommand(shortcut.Command, shortcut.CommandArgs)}
-                                onMouseEnter={() => setOpen(true)}
-                                onMouseLeave={() => setOpen(false)}
-                                className={`active:scale-y-[0.85] transition-transform`}>
-                            <img src={shortcut?.Icon?.Base64}
-                                 width={iconSize}
-                                 height={iconSize}
-                                 alt={shortcut.Name}/>
-                        </button>
-                    ) : (
-                        <button onClick={() => BrowserOpenURL(shortcut.Url)}
-                                onMouseEnter={() => setOpen(true)}
-                                onMouseLeave={() => setOpen(false)}
-                                className={`active:scale-y-[0.85] transition-transform`}>
-                            <img src={shortcut?.Icon?.Base64}
-                                 width={iconSize}
-                                 height={iconSize}
-                                 alt={shortcut.Name}/>
-                        </button>
-                    )}
-                </TooltipTrigger>
-                <TooltipContent className="dark text-[11px] px-1.5 py-0.4 select-none" side="bottom" sideOffset={1}>
-                    {shortcut.Name}
-                </TooltipContent>
-            </Tooltip>
-        </TooltipProvider>
+        <ContextMenu onOpenChange={() => setOpen(false)}>
+            <TooltipProvider key={shortcut.Id} delayDuration={0}>
+                <Tooltip open={open} onOpenChange={setOpen}>
+                    <ContextMenuTrigger asChild disabled={menuItems.length === 0}>
+                        <TooltipTrigger asChild>
+                            <button onClick={() => RunShortcut(shortcut.Id)}
+                                    onMouseEnter={() => setOpen(true)}
+                                    onMouseLeave={() => setOpen(false)}
+                                    className={`active:scale-y-[0.85] transition-transform`}>
+                                <img src={shortcut?.Icon?.Base64}
+                                     width={iconSize}
+                                     height={iconSize}
+                                     alt={shortcut.Name}/>
+                            </button>
+                        </TooltipTrigger>
+                    </ContextMenuTrigger>
+                    <TooltipContent className="dark text-[11px] px-1.5 py-0.4 select-none" side="bottom" sideOffset={1}>
+                        {shortcut.Name}
+                    </TooltipContent>
+                </Tooltip>
+            </TooltipProvider>
+            <ContextMenuContent className="dark text-[11px] select-none">
+                {menuItems.map((menuItem, index) => (
+                    <Fragment key={index}>
+                        {index > 0 && menuItem.BuiltIn && !menuItems[index - 1].BuiltIn && (
+                            <ContextMenuSeparator/>
+                        )}
+                        <ContextMenuItem className="text-[11px] py-1" onSelect={() => RunMenuItem(shortcut.Id, index)}>
+                            {menuItem.Label}
+                        </ContextMenuItem>
+                    </Fragment>
+                ))}
+            </ContextMenuContent>
+        </ContextMenu>
     );
 }
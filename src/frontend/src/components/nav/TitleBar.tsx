@@ -15,13 +15,21 @@
  */
 
 import {type CSSProperties, useEffect, useState} from "react";
-import {ChevronDown, X} from "lucide-react";
+import {Check, ChevronDown, TriangleAlert, X} from "lucide-react";
 import {Quit, WindowMinimise} from "../../../wailsjs/runtime";
 import {AppIconWithTooltip} from "@/components/nav/AppIconWithTooltip.tsx";
 import {frontend} from "../../../wailsjs/go/models.ts";
-import {IsBuildForJdvm} from "../../../wailsjs/go/cmd/Launchee";
+import {HideWindow, IsBuildForJdvm, SwitchProfile} from "../../../wailsjs/go/cmd/Launchee";
+import {DropdownMenu, DropdownMenuContent, DropdownMenuItem, DropdownMenuTrigger} from "@/components/ui/dropdown-menu.tsx";
+import {Tooltip, TooltipContent, TooltipProvider, TooltipTrigger} from "@/components/ui/tooltip.tsx";
 
-export function TitleBar({nav}: Readonly<{ nav: frontend.Nav | null }>) {
+export function TitleBar({nav, hideToTray, profiles, profile, invalidShortcuts}: Readonly<{
+    nav: frontend.Nav | null,
+    hideToTray: boolean,
+    profiles: string[],
+    profile: string,
+    invalidShortcuts: frontend.InvalidShortcut[]
+}>) {
     const defaultAppIconSize = 23;
     const defaultAppIconUrl = "https://launchee.jdheim.com";
     const defaultMenuHeight = 8;
@@ -46,12 +54,46 @@ export function TitleBar({nav}: Readonly<{ nav: frontend.Nav | null }>) {
                                     url={appIconUrl}/>
             </div>
             <div className="absolute left-1/2 transform -translate-x-1/2 text-gray-200 text-[13px] truncate max-w-[calc(100%-(20px+20px+5px)*2-4px-4px)]">
-                {nav?.Title && (
+                {nav?.Title && profiles.length === 0 && (
                     <span>{nav.Title}</span>
                 )}
+                {nav?.Title && profiles.length > 0 && (
+                    <DropdownMenu>
+                        <DropdownMenuTrigger className="flex flex-row items-center-safe gap-0.5 outline-hidden hover:text-gray-100"
+                                             style={{"--wails-draggable": "no-drag"} as CSSProperties}>
+                            <span className="truncate">{nav.Title}</span>
+                            <ChevronDown className="size-3.5 shrink-0 text-gray-400"/>
+                        </DropdownMenuTrigger>
+                        <DropdownMenuContent className="dark text-[11px] select-none" sideOffset={2}>
+                            {["", ...profiles].map(name => (
+                                <DropdownMenuItem key={name} className="text-[11px] py-1" onSelect={() => SwitchProfile(name)}>
+                                    <Check className={`size-3 ${name === profile ? "" : "invisible"}`}/>
+                                    {name || "Default"}
+                                </DropdownMenuItem>
+                            ))}
+                        </DropdownMenuContent>
+                    </DropdownMenu>
+                )}
             </div>
             <div className="flex flex-row mx-1 gap-1" style={{"--wails-draggable": "no-drag"} as CSSProperties}>
-                <ChevronDown className="size-5 text-gray-400 hover:text-gray-100 transition-colors duration-200 ease-in-out" onClick={() => WindowMinimise()}/>
+                {invalidShortcuts.length > 0 && (
+                    <TooltipProvider delayDuration={0}>
+                        <Tooltip>
+                            <TooltipTrigger asChild>
+                                <TriangleAlert className="size-5 p-0.5 text-amber-500"/>
+                            </TooltipTrigger>
+                            <TooltipContent className="dark text-[11px] px-1.5 py-0.5 max-w-80 select-none" sideOffset={5}>
+                                <p>Skipped invalid shortcuts:</p>
+                                <ul className="list-disc pl-3">
+                                    {invalidShortcuts.map(invalid => (
+                                        <li key={`${invalid.Profile}/${invalid.Name}`}>{invalid.Problem}</li>
+                                    ))}
+                                </ul>
+                            </TooltipContent>
+                        </Tooltip>
+                    </TooltipProvider>
+                )}
+                <ChevronDown className="size-5 text-gray-400 hover:text-gray-100 transition-colors duration-200 ease-in-out" onClick={() => hideToTray ? HideWindow() : WindowMinimise()}/>
                 <X className="size-5 text-red-700 hover:text-red-500 transition-colors duration-200 ease-in-out" onClick={() => Quit()}/>
             </div>
         </div>
@@ -8,8 +8,26 @@ export function GetConfig():Promise<frontend.Config>;
 
 export function GetCustomConfigPath():Promise<string>;
 
+export function GetRecentItems():Promise<Array<frontend.RecentItem>>;
+
+export function HideWindow():Promise<void>;
+
 export function IsBuildForJdvm():Promise<boolean>;
 
+export function LeaveWindow():Promise<void>;
+
+export function OpenRecentFile(arg1:string):Promise<void>;
+
+export function RevealWindow():Promise<void>;
+
+export function RunActions(arg1:Array<frontend.Action>):Promise<void>;
+
 export function RunCommand(arg1:string,arg2:Array<string>):Promise<void>;
 
+export function RunMenuItem(arg1:number,arg2:number):Promise<void>;
+
+export function RunShortcut(arg1:number):Promise<void>;
+
 export function SetCustomConfigPath(arg1:string):Promise<void>;
+
+export function SwitchProfile(arg1:string):Promise<void>;
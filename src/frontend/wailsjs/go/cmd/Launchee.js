@@ -14,14 +14,50 @@ export function GetCustomConfigPath() {
   return window['go']['cmd']['Launchee']['GetCustomConfigPath']();
 }
 
+export function GetRecentItems() {
+  return window['go']['cmd']['Launchee']['GetRecentItems']();
+}
+
+export function HideWindow() {
+  return window['go']['cmd']['Launchee']['HideWindow']();
+}
+
 export function IsBuildForJdvm() {
   return window['go']['cmd']['Launchee']['IsBuildForJdvm']();
 }
 
+export function LeaveWindow() {
+  return window['go']['cmd']['Launchee']['LeaveWindow']();
+}
+
+export function OpenRecentFile(arg1) {
+  return window['go']['cmd']['Launchee']['OpenRecentFile'](arg1);
+}
+
+export function RevealWindow() {
+  return window['go']['cmd']['Launchee']['RevealWindow']();
+}
+
+export function RunActions(arg1) {
+  return window['go']['cmd']['Launchee']['RunActions'](arg1);
+}
+
 export function RunCommand(arg1, arg2) {
   return window['go']['cmd']['Launchee']['RunCommand'](arg1, arg2);
 }
 
+export function RunMenuItem(arg1, arg2) {
+  return window['go']['cmd']['Launchee']['RunMenuItem'](arg1, arg2);
+}
+
+export function RunShortcut(arg1) {
+  return window['go']['cmd']['Launchee']['RunShortcut'](arg1);
+}
+
 export function SetCustomConfigPath(arg1) {
   return window['go']['cmd']['Launchee']['SetCustomConfigPath'](arg1);
 }
+
+export function SwitchProfile(arg1) {
+  return window['go']['cmd']['Launchee']['SwitchProfile'](arg1);
+}
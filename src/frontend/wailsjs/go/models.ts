@@ -1,12 +1,110 @@
 export namespace frontend {
 	
+	export class WaitFor {
+	    Exit: boolean;
+	    Delay: number;
+	    Port: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WaitFor(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Exit = source["Exit"];
+	        this.Delay = source["Delay"];
+	        this.Port = source["Port"];
+	    }
+	}
+	export class Action {
+	    Command: string;
+	    CommandArgs: string[];
+	    Url: string;
+	    Path: string;
+	    WaitFor?: WaitFor;
+	    Parallel: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Action(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Command = source["Command"];
+	        this.CommandArgs = source["CommandArgs"];
+	        this.Url = source["Url"];
+	        this.Path = source["Path"];
+	        this.WaitFor = this.convertValues(source["WaitFor"], WaitFor);
+	        this.Parallel = source["Parallel"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MenuItem {
+	    Label: string;
+	    Command: string;
+	    CommandArgs: string[];
+	    Url: string;
+	    Path: string;
+	    BuiltIn: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MenuItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Label = source["Label"];
+	        this.Command = source["Command"];
+	        this.CommandArgs = source["CommandArgs"];
+	        this.Url = source["Url"];
+	        this.Path = source["Path"];
+	        this.BuiltIn = source["BuiltIn"];
+	    }
+	}
+	export class Browser {
+	    Command: string;
+	    CommandArgs: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Browser(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Command = source["Command"];
+	        this.CommandArgs = source["CommandArgs"];
+	    }
+	}
 	export class Shortcut {
 	    Id: number;
+	    StableId: string;
 	    Name: string;
 	    Icon?: Icon;
 	    Command: string;
 	    CommandArgs: string[];
 	    Url: string;
+	    Path: string;
+	    Browser?: Browser;
+	    AfterLaunch: string;
+	    Actions: Action[];
+	    MenuItems: MenuItem[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Shortcut(source);
@@ -15,11 +113,17 @@ export namespace frontend {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.Id = source["Id"];
+	        this.StableId = source["StableId"];
 	        this.Name = source["Name"];
 	        this.Icon = this.convertValues(source["Icon"], Icon);
 	        this.Command = source["Command"];
 	        this.CommandArgs = source["CommandArgs"];
 	        this.Url = source["Url"];
+	        this.Path = source["Path"];
+	        this.Browser = this.convertValues(source["Browser"], Browser);
+	        this.AfterLaunch = source["AfterLaunch"];
+	        this.Actions = this.convertValues(source["Actions"], Action);
+	        this.MenuItems = this.convertValues(source["MenuItems"], MenuItem);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -115,6 +219,7 @@ export namespace frontend {
 	export class UI {
 	    Nav?: Nav;
 	    Content?: Content;
+	    Recent?: Content;
 	
 	    static createFrom(source: any = {}) {
 	        return new UI(source);
@@ -124,6 +229,103 @@ export namespace frontend {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.Nav = this.convertValues(source["Nav"], Nav);
 	        this.Content = this.convertValues(source["Content"], Content);
+	        this.Recent = this.convertValues(source["Recent"], Content);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Behavior {
+	    AfterLaunch: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Behavior(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.AfterLaunch = source["AfterLaunch"];
+	    }
+	}
+	export class AutoHide {
+	    Enabled: boolean;
+	    Delay: number;
+	    Mode: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AutoHide(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Enabled = source["Enabled"];
+	        this.Delay = source["Delay"];
+	        this.Mode = source["Mode"];
+	    }
+	}
+	export class Tray {
+	    Enabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Tray(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Enabled = source["Enabled"];
+	    }
+	}
+	export class RecentItem {
+	    Name: string;
+	    Path: string;
+	    ShortcutId: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Path = source["Path"];
+	        this.ShortcutId = source["ShortcutId"];
+	    }
+	}
+	export class Recent {
+	    Limit: number;
+	    Files: boolean;
+	    Launches: boolean;
+	    MimeTypes: string[];
+	    Applications: string[];
+	    Items: RecentItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Recent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Limit = source["Limit"];
+	        this.Files = source["Files"];
+	        this.Launches = source["Launches"];
+	        this.MimeTypes = source["MimeTypes"];
+	        this.Applications = source["Applications"];
+	        this.Items = this.convertValues(source["Items"], RecentItem);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -144,9 +346,51 @@ export namespace frontend {
 		    return a;
 		}
 	}
+	export class InvalidShortcut {
+	    Name: string;
+	    Profile: string;
+	    Problem: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InvalidShortcut(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Profile = source["Profile"];
+	        this.Problem = source["Problem"];
+	    }
+	}
+	export class SkippedShortcut {
+	    Name: string;
+	    Profile: string;
+	    Reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SkippedShortcut(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Profile = source["Profile"];
+	        this.Reason = source["Reason"];
+	    }
+	}
 	export class Config {
 	    UI?: UI;
 	    Shortcuts: Shortcut[];
+	    ControlApi: boolean;
+	    DBus: boolean;
+	    Tray?: Tray;
+	    AutoHide?: AutoHide;
+	    Behavior?: Behavior;
+	    Recent?: Recent;
+	    Profiles: string[];
+	    Profile: string;
+	    SkippedShortcuts: SkippedShortcut[];
+	    InvalidShortcuts: InvalidShortcut[];
 	    Valid: boolean;
 	
 	    static createFrom(source: any = {}) {
@@ -157,6 +401,16 @@ export namespace frontend {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.UI = this.convertValues(source["UI"], UI);
 	        this.Shortcuts = this.convertValues(source["Shortcuts"], Shortcut);
+	        this.ControlApi = source["ControlApi"];
+	        this.DBus = source["DBus"];
+	        this.Tray = this.convertValues(source["Tray"], Tray);
+	        this.AutoHide = this.convertValues(source["AutoHide"], AutoHide);
+	        this.Behavior = this.convertValues(source["Behavior"], Behavior);
+	        this.Recent = this.convertValues(source["Recent"], Recent);
+	        this.Profiles = source["Profiles"];
+	        this.Profile = source["Profile"];
+	        this.SkippedShortcuts = this.convertValues(source["SkippedShortcuts"], SkippedShortcut);
+	        this.InvalidShortcuts = this.convertValues(source["InvalidShortcuts"], InvalidShortcut);
 	        this.Valid = source["Valid"];
 	    }
 	
@@ -16,24 +16,185 @@
 
 package frontend
 
+import (
+	"strings"
+	"time"
+)
+
 type Config struct {
-	UI        *UI
-	Shortcuts []*Shortcut
-	Valid     bool
+	UI         *UI
+	Shortcuts  []*Shortcut
+	ControlApi bool
+	DBus       bool
+	Tray       *Tray
+	AutoHide   *AutoHide
+	Behavior   *Behavior
+	Recent     *Recent
+	Profiles   []string
+	Profile    string
+	// SkippedShortcuts are the shortcuts whose when clause is not met on this machine
+	SkippedShortcuts []*SkippedShortcut
+	// InvalidShortcuts are the shortcuts dropped by the lenient validation
+	InvalidShortcuts []*InvalidShortcut
+	Valid            bool
 }
 
 type Shortcut struct {
-	Id          int
+	Id          int    // The position of the shortcut in the dock
+	StableId    string // The id of the shortcut in the config or its name, which stays the same across reloads
 	Name        string
 	Icon        *Icon
 	Command     string
 	CommandArgs []string
 	Url         string
+	Path        string
+	Browser     *Browser
+	AfterLaunch string
+	Actions     []*Action
+	MenuItems   []*MenuItem
+}
+
+type SkippedShortcut struct {
+	Name    string
+	Profile string
+	Reason  string
+}
+
+type InvalidShortcut struct {
+	Name    string
+	Profile string
+	Problem string
+}
+
+type Tray struct {
+	Enabled bool
+}
+
+type Behavior struct {
+	AfterLaunch string
+}
+
+type AutoHide struct {
+	Enabled bool
+	Delay   time.Duration
+	Mode    string
+}
+
+type Recent struct {
+	Limit        int
+	Files        bool
+	Launches     bool
+	MimeTypes    []string
+	Applications []string
+	Items        []*RecentItem
+}
+
+// RecentItem is a recently used file, opened by its path, or a recently launched shortcut.
+type RecentItem struct {
+	Name       string
+	Path       string
+	ShortcutId int
+}
+
+type Browser struct {
+	Command     string
+	CommandArgs []string
+}
+
+type Action struct {
+	Command     string
+	CommandArgs []string
+	Url         string
+	Path        string
+	WaitFor     *WaitFor
+	Parallel    bool
 }
 
+type WaitFor struct {
+	Exit  bool
+	Delay time.Duration
+	Port  string
+}
+
+type MenuItem struct {
+	Label       string
+	Command     string
+	CommandArgs []string
+	Url         string
+	Path        string
+	BuiltIn     string
+}
+
+const urlPlaceholder = "{url}"
+
+const (
+	AfterLaunchKeep     = "keep"
+	AfterLaunchMinimise = "minimise"
+	AfterLaunchHide     = "hide"
+	AfterLaunchQuit     = "quit"
+)
+
+const (
+	AutoHideModeStrip    = "strip"
+	AutoHideModeHide     = "hide"
+	defaultAutoHideDelay = 3 * time.Second
+)
+
+const (
+	MenuItemOpenContainingFolder = "openContainingFolder"
+	MenuItemCopyCommand          = "copyCommand"
+	MenuItemShowLog              = "showLog"
+	MenuItemStopProcess          = "stopProcess"
+)
+
 func NewConfig(shortcutCount int) *Config {
 	return &Config{
 		UI:    NewUI(shortcutCount),
 		Valid: true,
 	}
 }
+
+// NewAutoHide returns the auto-hide defaults, which collapse the dock to a strip.
+func NewAutoHide() *AutoHide {
+	return &AutoHide{
+		Delay: defaultAutoHideDelay,
+		Mode:  AutoHideModeStrip,
+	}
+}
+
+// NewRecent returns the recent section defaults, which list both files and launches.
+func NewRecent(limit int) *Recent {
+	return &Recent{
+		Limit:    limit,
+		Files:    true,
+		Launches: true,
+	}
+}
+
+// NewBuiltInMenuItems returns the menu items available for every shortcut with a command.
+func NewBuiltInMenuItems() []*MenuItem {
+	return []*MenuItem{
+		{Label: "Open containing folder", BuiltIn: MenuItemOpenContainingFolder},
+		{Label: "Copy command", BuiltIn: MenuItemCopyCommand},
+		{Label: "Show log", BuiltIn: MenuItemShowLog},
+		{Label: "Stop process", BuiltIn: MenuItemStopProcess},
+	}
+}
+
+// Args returns the arguments that open the URL in the browser. The URL replaces every {url} placeholder or is
+// appended when there is none.
+func (b *Browser) Args(url string) []string {
+	args := make([]string, 0, len(b.CommandArgs)+1)
+	replaced := false
+	for _, arg := range b.CommandArgs {
+		if strings.Contains(arg, urlPlaceholder) {
+			arg = strings.ReplaceAll(arg, urlPlaceholder, url)
+			replaced = true
+		}
+		args = append(args, arg)
+	}
+	if !replaced {
+		args = append(args, url)
+	}
+	return args
+}
@@ -18,6 +18,7 @@ package frontend
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -48,3 +49,51 @@ func newDefaultWantConfig() *Config {
 		Valid:     true,
 	}
 }
+
+func TestNewAutoHide(t *testing.T) {
+	want := &AutoHide{Delay: 3 * time.Second, Mode: AutoHideModeStrip}
+	if diff := cmp.Diff(want, NewAutoHide()); diff != "" {
+		t.Errorf("NewAutoHide() = diff -want +got\n%s", diff)
+	}
+}
+
+func TestNewRecent(t *testing.T) {
+	want := &Recent{Limit: 5, Files: true, Launches: true}
+	if diff := cmp.Diff(want, NewRecent(5)); diff != "" {
+		t.Errorf("NewRecent() = diff -want +got\n%s", diff)
+	}
+}
+
+func TestNewBuiltInMenuItems(t *testing.T) {
+	got := NewBuiltInMenuItems()
+	want := []string{MenuItemOpenContainingFolder, MenuItemCopyCommand, MenuItemShowLog, MenuItemStopProcess}
+	if len(got) != len(want) {
+		t.Fatalf("NewBuiltInMenuItems() = %d items, want %d", len(got), len(want))
+	}
+	for i, menuItem := range got {
+		if menuItem.BuiltIn != want[i] || menuItem.Label == "" {
+			t.Errorf("NewBuiltInMenuItems()[%d] = %+v, want built-in %q with a label", i, menuItem, want[i])
+		}
+	}
+}
+
+func TestBrowserArgs(t *testing.T) {
+	testCases := map[string]struct {
+		in   *Browser
+		want []string
+	}{
+		"no args":          {&Browser{Command: "firefox"}, []string{"https://example.com"}},
+		"no placeholder":   {&Browser{Command: "firefox", CommandArgs: []string{"-P", "work"}}, []string{"-P", "work", "https://example.com"}},
+		"placeholder":      {&Browser{Command: "firefox", CommandArgs: []string{"-P", "work", "--new-tab", "{url}"}}, []string{"-P", "work", "--new-tab", "https://example.com"}},
+		"placeholder part": {&Browser{Command: "chromium", CommandArgs: []string{"--app={url}"}}, []string{"--app=https://example.com"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := testCase.in.Args("https://example.com")
+			if diff := cmp.Diff(testCase.want, got); diff != "" {
+				t.Errorf("Args() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import "github.com/jdheim/launchee/build"
 type UI struct {
 	Nav     *Nav
 	Content *Content
+	Recent  *Content
 }
 
 type Nav struct {
@@ -47,6 +48,7 @@ const (
 	defaultMinIconsPerRow = 5
 	defaultMaxIconsPerRow = 20
 	defaultIconSize       = 8
+	defaultRecentIconSize = 6
 	defaultMargin         = 5
 	defaultBorder         = 1
 	spacingScale          = 4
@@ -79,6 +81,16 @@ func NewContent(shortcutCount int) *Content {
 	}
 }
 
+// NewRecentContent returns the layout of the recent section: a single row of smaller icons below the shortcuts.
+func NewRecentContent(itemCount int, iconsPerRow int) *Content {
+	return &Content{
+		IconColumns: min(itemCount, iconsPerRow),
+		IconsPerRow: iconsPerRow,
+		IconSize:    defaultRecentIconSize * spacingScale,
+		Margin:      defaultMargin,
+	}
+}
+
 func determineIconLayout(shortcutCount int) (int, int) {
 	iconColumns, iconsPerRow := shortcutCount, shortcutCount
 	if shortcutCount == 0 {
@@ -103,7 +115,11 @@ func (u *UI) Width() int {
 func (u *UI) Height(shortcutCount int) int {
 	shortcutCount = max(defaultMinIconsPerRow, shortcutCount)
 	rows := (shortcutCount + u.Content.IconsPerRow - 1) / u.Content.IconsPerRow
-	return defaultBorder + u.Content.Margin*spacingScale*(rows+1) +
+	height := defaultBorder + u.Content.Margin*spacingScale*(rows+1) +
 		u.Content.IconSize*rows +
 		u.Nav.MenuHeight*spacingScale
+	if u.Recent != nil {
+		height += u.Recent.IconSize + u.Recent.Margin*spacingScale
+	}
+	return height
 }
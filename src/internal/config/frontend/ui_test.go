@@ -130,6 +130,34 @@ func TestHeight(t *testing.T) {
 	}
 }
 
+func TestNewRecentContent(t *testing.T) {
+	testCases := map[string]struct {
+		itemCount   int
+		iconsPerRow int
+		want        *Content
+	}{
+		"fewer": {3, 5, &Content{IconColumns: 3, IconsPerRow: 5, IconSize: 24, Margin: 5}},
+		"more":  {20, 15, &Content{IconColumns: 15, IconsPerRow: 15, IconSize: 24, Margin: 5}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := NewRecentContent(testCase.itemCount, testCase.iconsPerRow)
+			if diff := cmp.Diff(testCase.want, got); diff != "" {
+				t.Errorf("NewRecentContent(%d, %d) = diff -want +got\n%s", testCase.itemCount, testCase.iconsPerRow, diff)
+			}
+		})
+	}
+}
+
+func TestHeightWithRecent(t *testing.T) {
+	testUI := NewUI(5)
+	testUI.Recent = NewRecentContent(5, testUI.Content.IconsPerRow)
+	if got, want := testUI.Height(5), 149; got != want {
+		t.Errorf("Height(5) = %d, want %d", got, want)
+	}
+}
+
 func newDefaultWantUI() *UI {
 	return &UI{
 		Nav: &Nav{
@@ -0,0 +1,134 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/shlex"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	commandArgsAppend  = "$append"
+	commandArgsPrepend = "$prepend"
+)
+
+// commandArgs are the arguments of a command given either as a string split the way a shell would, e.g. "-P work", or
+// as a list, e.g. ["-P", "work"]. In merge mode, they may instead extend the inherited ones with {$append: [...]} or
+// {$prepend: [...]}.
+type commandArgs struct {
+	Line    string
+	List    []string
+	Append  []string
+	Prepend []string
+}
+
+func (a *commandArgs) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		return value.Decode(&a.Line)
+	case yaml.SequenceNode:
+		return value.Decode(&a.List)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			var err error
+			switch key := value.Content[i].Value; key {
+			case commandArgsAppend:
+				err = value.Content[i+1].Decode(&a.Append)
+			case commandArgsPrepend:
+				err = value.Content[i+1].Decode(&a.Prepend)
+			default:
+				err = errors.Errorf("line %d: Command Args must be a string, a list or have \"%s\" or \"%s\" (got \"%s\")",
+					value.Content[i].Line, commandArgsAppend, commandArgsPrepend, key)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errors.Errorf("line %d: Command Args must be a string, a list or have \"%s\" or \"%s\"", value.Line,
+			commandArgsAppend, commandArgsPrepend)
+	}
+}
+
+// String returns the arguments the way they are given in the config, for messages.
+func (a *commandArgs) String() string {
+	switch {
+	case a == nil:
+		return ""
+	case a.isPatch():
+		return fmt.Sprintf("%s: %q, %s: %q", commandArgsPrepend, a.Prepend, commandArgsAppend, a.Append)
+	case a.List != nil:
+		return fmt.Sprintf("%q", a.List)
+	default:
+		return a.Line
+	}
+}
+
+func (a *commandArgs) trim() {
+	if a != nil {
+		a.Line = strings.TrimSpace(a.Line)
+	}
+}
+
+func (a *commandArgs) isSet() bool {
+	return a != nil && (a.Line != "" || a.List != nil || a.isPatch())
+}
+
+// isPatch tells whether the arguments extend the inherited ones instead of replacing them.
+func (a *commandArgs) isPatch() bool {
+	return a != nil && (a.Append != nil || a.Prepend != nil)
+}
+
+// split returns the arguments as a list, splitting the string the way a shell would.
+func (a *commandArgs) split() ([]string, error) {
+	switch {
+	case a == nil:
+		return nil, nil
+	case a.List != nil:
+		return a.List, nil
+	case a.Line != "":
+		return shlex.Split(a.Line)
+	default:
+		return slices.Concat(a.Prepend, a.Append), nil
+	}
+}
+
+// args returns the arguments of a validated config as a list.
+func (a *commandArgs) args() []string {
+	args, _ := a.split()
+	if len(args) == 0 {
+		return nil
+	}
+	return args
+}
+
+// patched returns the arguments replaced by the other ones or, in merge mode, extended by them.
+func (a *commandArgs) patched(other *commandArgs) *commandArgs {
+	if !other.isSet() {
+		return a
+	}
+	if !other.isPatch() {
+		return other
+	}
+	return &commandArgs{List: slices.Concat(other.Prepend, a.args(), other.Append)}
+}
@@ -0,0 +1,100 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCommandArgsUnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		in      string
+		want    *commandArgs
+		wantErr bool
+	}{
+		"string":      {`commandArgs: "-P work"`, &commandArgs{Line: "-P work"}, false},
+		"list":        {`commandArgs: ["-P", "work profile"]`, &commandArgs{List: []string{"-P", "work profile"}}, false},
+		"empty list":  {`commandArgs: []`, &commandArgs{List: []string{}}, false},
+		"append":      {`commandArgs: {$append: ["--incognito"]}`, &commandArgs{Append: []string{"--incognito"}}, false},
+		"prepend":     {`commandArgs: {$prepend: ["-v"], $append: ["-q"]}`, &commandArgs{Prepend: []string{"-v"}, Append: []string{"-q"}}, false},
+		"missing":     {`name: "Terminal"`, nil, false},
+		"unknown key": {`commandArgs: {$insert: ["-v"]}`, nil, true},
+		"nested list": {`commandArgs: [["-v"]]`, nil, true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got shortcut
+			err := yaml.Unmarshal([]byte(testCase.in), &got)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Fatalf("UnmarshalYAML() = %v, want error %t", err, testCase.wantErr)
+			}
+			if diff := cmp.Diff(testCase.want, got.CommandArgs); !testCase.wantErr && diff != "" {
+				t.Errorf("UnmarshalYAML() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommandArgsArgs(t *testing.T) {
+	testCases := map[string]struct {
+		in   *commandArgs
+		want []string
+	}{
+		"nil":                {nil, nil},
+		"empty":              {&commandArgs{Line: ""}, nil},
+		"2 args":             {&commandArgs{Line: "foo bar"}, []string{"foo", "bar"}},
+		"2 args with spaces": {&commandArgs{Line: "'foo bar' 'baz'"}, []string{"foo bar", "baz"}},
+		"4 args":             {&commandArgs{Line: "-d /tmp/dummy -f foo.txt"}, []string{"-d", "/tmp/dummy", "-f", "foo.txt"}},
+		"4 args with spaces": {&commandArgs{Line: "-s \"space test\" -f foo.txt"}, []string{"-s", "space test", "-f", "foo.txt"}},
+		"list":               {&commandArgs{List: []string{"-s", "space test"}}, []string{"-s", "space test"}},
+		"empty list":         {&commandArgs{List: []string{}}, nil},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(testCase.want, testCase.in.args()); diff != "" {
+				t.Errorf("args() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommandArgsPatched(t *testing.T) {
+	inherited := &commandArgs{Line: "-P work"}
+	testCases := map[string]struct {
+		in   *commandArgs
+		want *commandArgs
+	}{
+		"nil":     {nil, inherited},
+		"string":  {&commandArgs{Line: "-P home"}, &commandArgs{Line: "-P home"}},
+		"list":    {&commandArgs{List: []string{"-P", "home"}}, &commandArgs{List: []string{"-P", "home"}}},
+		"append":  {&commandArgs{Append: []string{"--new-tab"}}, &commandArgs{List: []string{"-P", "work", "--new-tab"}}},
+		"prepend": {&commandArgs{Prepend: []string{"-v"}, Append: []string{"-q"}}, &commandArgs{List: []string{"-v", "-P", "work", "-q"}}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(testCase.want, inherited.patched(testCase.in)); diff != "" {
+				t.Errorf("patched() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,24 +17,123 @@
 package yaml
 
 import (
+	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/shlex"
 	"github.com/jdheim/launchee/internal/config/frontend"
 )
 
 type config struct {
+	Schema     string `yaml:"$schema"` // The JSON Schema for editors of JSON configs, which have no comments
+	Version    int
+	Title      string
+	UrlSchemes []string `yaml:"urlSchemes"`
+	Browsers   []*browser
+	ControlApi *bool `yaml:"controlApi"`
+	DBus       *bool `yaml:"dbus"`
+	Tray       *tray
+	AutoHide   *autoHide `yaml:"autoHide"`
+	Behavior   *behavior
+	Sort       string
+	Recent     *recent
+	Validation string
+	Remote     *remote
+	Policy     *policy
+	Shortcuts  []*shortcut
+	Profiles   []*profile
+	Skipped    []*frontend.SkippedShortcut `yaml:"-"`
+	Invalid    []*frontend.InvalidShortcut `yaml:"-"`
+	// The URL schemes of the configs this one is merged over, which its shortcuts may use too
+	InheritedUrlSchemes []string `yaml:"-"`
+}
+
+// profile patches the shortcuts of the config the same way the user config patches the system one.
+type profile struct {
+	Name      string
 	Title     string
 	Shortcuts []*shortcut
 }
 
+// remote is a config served over HTTP, e.g. the shared one of an organization, merged over the config that sets it.
+type remote struct {
+	Url     string
+	Sha256  string
+	Timeout string
+}
+
+type tray struct {
+	Enabled *bool
+}
+
+type behavior struct {
+	AfterLaunch string `yaml:"afterLaunch"`
+}
+
+type autoHide struct {
+	Enabled *bool
+	Delay   string
+	Mode    string
+}
+
+type recent struct {
+	Enabled      *bool
+	Limit        int
+	Files        *bool
+	Launches     *bool
+	MimeTypes    []string `yaml:"mimeTypes"`
+	Applications []string
+}
+
+type browser struct {
+	Name        string
+	Command     string
+	CommandArgs *commandArgs `yaml:"commandArgs"`
+}
+
 type shortcut struct {
+	Id          string // Matches the shortcut across the configs instead of its name, which may change
 	Name        string
 	Icon        string
 	Command     string
-	CommandArgs string `yaml:"commandArgs"`
+	CommandArgs *commandArgs `yaml:"commandArgs"`
 	Url         string
+	Path        string
+	Browser     string
+	AfterLaunch string `yaml:"afterLaunch"`
+	Actions     []*action
+	Menu        []*menuItem
+	When        *when
 	Patch       string `yaml:"$patch"`
+	NewName     string `yaml:"newName"` // The name given by $patch: rename
+	Before      string // Places the shortcut before the one of that name or id
+	After       string // Places the shortcut after the one of that name or id
+	Position    int    // Places the shortcut at the position, 1 being the first
+}
+
+type action struct {
+	Command     string
+	CommandArgs *commandArgs `yaml:"commandArgs"`
+	Url         string
+	Path        string
+	WaitFor     *waitFor `yaml:"waitFor"`
+	Parallel    bool
+}
+
+type menuItem struct {
+	Label       string
+	Command     string
+	CommandArgs *commandArgs `yaml:"commandArgs"`
+	Url         string
+	Path        string
+}
+
+type waitFor struct {
+	Exit  bool
+	Delay string
+	Port  string
 }
 
 // Creates a new config without shortcuts.
@@ -44,23 +143,123 @@ func newConfigWithoutShortcuts(title string) *config {
 	}
 }
 
+// Returns the URL schemes allowed in the config: the default ones followed by the inherited and the configured ones.
+func (yc *config) allowedUrlSchemes() []string {
+	return slices.Concat([]string{urlSchemeHttp, urlSchemeHttps}, yc.InheritedUrlSchemes, yc.UrlSchemes)
+}
+
 // Trims all strings in the config.
 func (yc *config) trim() {
 	if yc == nil {
 		return
 	}
 	yc.Title = strings.TrimSpace(yc.Title)
-	for _, shortcut := range yc.Shortcuts {
+	yc.Sort = strings.TrimSpace(yc.Sort)
+	yc.Validation = strings.TrimSpace(yc.Validation)
+	if yc.Remote != nil {
+		yc.Remote.Url = strings.TrimSpace(yc.Remote.Url)
+		yc.Remote.Sha256 = strings.ToLower(strings.TrimSpace(yc.Remote.Sha256))
+		yc.Remote.Timeout = strings.TrimSpace(yc.Remote.Timeout)
+	}
+	if yc.Policy != nil {
+		for i, name := range yc.Policy.LockedShortcuts {
+			yc.Policy.LockedShortcuts[i] = strings.TrimSpace(name)
+		}
+		for i, prefix := range yc.Policy.AllowedCommandPrefixes {
+			yc.Policy.AllowedCommandPrefixes[i] = strings.TrimSpace(prefix)
+		}
+	}
+	for i, urlScheme := range yc.UrlSchemes {
+		yc.UrlSchemes[i] = strings.ToLower(strings.TrimSpace(urlScheme))
+	}
+	if yc.Behavior != nil {
+		yc.Behavior.AfterLaunch = strings.TrimSpace(yc.Behavior.AfterLaunch)
+	}
+	if yc.AutoHide != nil {
+		yc.AutoHide.Delay = strings.TrimSpace(yc.AutoHide.Delay)
+		yc.AutoHide.Mode = strings.TrimSpace(yc.AutoHide.Mode)
+	}
+	if yc.Recent != nil {
+		for i, mimeType := range yc.Recent.MimeTypes {
+			yc.Recent.MimeTypes[i] = strings.ToLower(strings.TrimSpace(mimeType))
+		}
+		for i, application := range yc.Recent.Applications {
+			yc.Recent.Applications[i] = strings.TrimSpace(application)
+		}
+	}
+	for _, browser := range yc.Browsers {
+		if browser == nil {
+			continue
+		}
+		browser.Name = strings.TrimSpace(browser.Name)
+		browser.Command = strings.TrimSpace(browser.Command)
+		browser.CommandArgs.trim()
+	}
+	trimShortcuts(yc.Shortcuts)
+	for _, profile := range yc.Profiles {
+		if profile == nil {
+			continue
+		}
+		profile.Name = strings.TrimSpace(profile.Name)
+		profile.Title = strings.TrimSpace(profile.Title)
+		trimShortcuts(profile.Shortcuts)
+	}
+}
+
+// Trims all strings in the shortcuts.
+func trimShortcuts(shortcuts []*shortcut) {
+	for _, shortcut := range shortcuts {
 		if shortcut == nil {
 			continue
 		}
+		shortcut.Id = strings.TrimSpace(shortcut.Id)
 		shortcut.Name = strings.TrimSpace(shortcut.Name)
 		shortcut.Icon = strings.TrimSpace(shortcut.Icon)
 		shortcut.Command = strings.TrimSpace(shortcut.Command)
-		shortcut.CommandArgs = strings.TrimSpace(shortcut.CommandArgs)
+		shortcut.CommandArgs.trim()
 		shortcut.Url = strings.TrimSpace(shortcut.Url)
+		shortcut.Path = strings.TrimSpace(shortcut.Path)
+		shortcut.Browser = strings.TrimSpace(shortcut.Browser)
+		shortcut.AfterLaunch = strings.TrimSpace(shortcut.AfterLaunch)
 		shortcut.Patch = strings.TrimSpace(shortcut.Patch)
+		shortcut.NewName = strings.TrimSpace(shortcut.NewName)
+		shortcut.Before = strings.TrimSpace(shortcut.Before)
+		shortcut.After = strings.TrimSpace(shortcut.After)
+		shortcut.When.trim()
+		for _, action := range shortcut.Actions {
+			action.trim()
+		}
+		for _, menuItem := range shortcut.Menu {
+			menuItem.trim()
+		}
+	}
+}
+
+// Trims all strings in the action.
+func (a *action) trim() {
+	if a == nil {
+		return
+	}
+	a.Command = strings.TrimSpace(a.Command)
+	a.CommandArgs.trim()
+	a.Url = strings.TrimSpace(a.Url)
+	a.Path = strings.TrimSpace(a.Path)
+	if a.WaitFor != nil {
+		a.WaitFor.Delay = strings.TrimSpace(a.WaitFor.Delay)
+		a.WaitFor.Port = strings.TrimSpace(a.WaitFor.Port)
+	}
+}
+
+// Trims all strings in the menu item.
+func (m *menuItem) trim() {
+	if m == nil {
+		return
 	}
+	m.Label = strings.TrimSpace(m.Label)
+	m.Command = strings.TrimSpace(m.Command)
+	m.CommandArgs.trim()
+	m.Url = strings.TrimSpace(m.Url)
+	m.Path = strings.TrimSpace(m.Path)
 }
 
 // Converts the config to a frontend.Config.
@@ -78,9 +277,34 @@ func (yc *config) overrideFrontendConfig(config *frontend.Config) {
 	if yc.Title != "" {
 		config.UI.Nav.Title = yc.Title
 	}
+	if yc.ControlApi != nil {
+		config.ControlApi = *yc.ControlApi
+	}
+	if yc.DBus != nil {
+		config.DBus = *yc.DBus
+	}
+	if yc.Tray != nil && yc.Tray.Enabled != nil {
+		config.Tray = &frontend.Tray{Enabled: *yc.Tray.Enabled}
+	}
+	if yc.AutoHide != nil {
+		config.AutoHide = yc.AutoHide.toFrontendAutoHide()
+	}
+	if yc.Behavior != nil && yc.Behavior.AfterLaunch != "" {
+		config.Behavior = &frontend.Behavior{AfterLaunch: yc.Behavior.AfterLaunch}
+	}
 	if frontendShortcuts := yc.toFrontendShortcuts(); frontendShortcuts != nil {
 		config.Shortcuts = frontendShortcuts
 	}
+	for _, profile := range yc.Profiles {
+		config.Profiles = append(config.Profiles, profile.Name)
+	}
+	config.SkippedShortcuts = yc.Skipped
+	config.InvalidShortcuts = yc.Invalid
+	if yc.Recent != nil && yc.Recent.Enabled != nil && *yc.Recent.Enabled {
+		config.Recent = yc.Recent.toFrontendRecent(config.UI.Content.IconsPerRow)
+		config.Recent.Items = LoadRecentItems(config.Recent, config.Shortcuts)
+		config.UI.Recent = frontend.NewRecentContent(config.Recent.Limit, config.UI.Content.IconsPerRow)
+	}
 }
 
 // Converts the shortcut(s) to a frontend.Shortcut(s).
@@ -90,31 +314,228 @@ func (yc *config) toFrontendShortcuts() []*frontend.Shortcut {
 		return nil
 	}
 	frontendShortcuts := make([]*frontend.Shortcut, shortcutCount)
-	for i := range yc.Shortcuts {
-		frontendShortcuts[i] = yc.toFrontendShortcut(i)
+	// The shortcuts are sorted first, as the ids are their positions
+	for i, shortcut := range yc.sortedShortcuts() {
+		frontendShortcuts[i] = yc.toFrontendShortcut(i, shortcut)
 	}
 	return frontendShortcuts
 }
 
 // Converts the shortcut to a frontend.Shortcut.
-func (yc *config) toFrontendShortcut(i int) *frontend.Shortcut {
+func (yc *config) toFrontendShortcut(id int, s *shortcut) *frontend.Shortcut {
 	return &frontend.Shortcut{
-		Id:          i,
-		Name:        yc.Shortcuts[i].Name,
-		Icon:        frontend.NewIcon(yc.Shortcuts[i].Icon),
-		Command:     yc.Shortcuts[i].Command,
-		CommandArgs: yc.Shortcuts[i].parseCommandArgs(),
-		Url:         yc.Shortcuts[i].Url,
+		Id:          id,
+		StableId:    s.key(),
+		Name:        s.Name,
+		Icon:        frontend.NewIcon(s.Icon),
+		Command:     s.Command,
+		CommandArgs: s.CommandArgs.args(),
+		Url:         s.Url,
+		Path:        s.Path,
+		Browser:     yc.toFrontendBrowser(s),
+		AfterLaunch: s.AfterLaunch,
+		Actions:     s.toFrontendActions(),
+		MenuItems:   s.toFrontendMenuItems(),
 	}
 }
 
-// Parses the command arguments.
-func (s *shortcut) parseCommandArgs() []string {
-	if s == nil || s.CommandArgs == "" {
+// Converts the menu item(s) to a frontend.MenuItem(s), followed by the built-in ones.
+func (s *shortcut) toFrontendMenuItems() []*frontend.MenuItem {
+	frontendMenuItems := make([]*frontend.MenuItem, 0, len(s.Menu))
+	for _, menuItem := range s.Menu {
+		frontendMenuItems = append(frontendMenuItems, &frontend.MenuItem{
+			Label:       menuItem.Label,
+			Command:     menuItem.Command,
+			CommandArgs: menuItem.CommandArgs.args(),
+			Url:         menuItem.Url,
+			Path:        menuItem.Path,
+		})
+	}
+	if s.Command != "" {
+		frontendMenuItems = append(frontendMenuItems, frontend.NewBuiltInMenuItems()...)
+	}
+	if len(frontendMenuItems) == 0 {
 		return nil
 	}
-	commandArgParts, _ := shlex.Split(s.CommandArgs)
-	return commandArgParts
+	return frontendMenuItems
+}
+
+// Converts the action(s) to a frontend.Action(s).
+func (s *shortcut) toFrontendActions() []*frontend.Action {
+	if len(s.Actions) == 0 {
+		return nil
+	}
+	frontendActions := make([]*frontend.Action, len(s.Actions))
+	for i, action := range s.Actions {
+		frontendActions[i] = action.toFrontendAction()
+	}
+	return frontendActions
+}
+
+// Converts the action to a frontend.Action.
+func (a *action) toFrontendAction() *frontend.Action {
+	frontendAction := &frontend.Action{
+		Command:     a.Command,
+		CommandArgs: a.CommandArgs.args(),
+		Url:         a.Url,
+		Path:        a.Path,
+		Parallel:    a.Parallel,
+	}
+	if a.WaitFor != nil {
+		delay, _ := time.ParseDuration(a.WaitFor.Delay)
+		frontendAction.WaitFor = &frontend.WaitFor{
+			Exit:  a.WaitFor.Exit,
+			Delay: delay,
+			Port:  a.WaitFor.Port,
+		}
+	}
+	return frontendAction
+}
+
+// Converts the browser of the shortcut to a frontend.Browser. The browser is either the name of a browser defined in
+// the config or a command template.
+func (yc *config) toFrontendBrowser(s *shortcut) *frontend.Browser {
+	if s.Browser == "" {
+		return nil
+	}
+	if browser := findBrowser(yc.Browsers, s.Browser); browser != nil {
+		return &frontend.Browser{
+			Command:     browser.Command,
+			CommandArgs: browser.CommandArgs.args(),
+		}
+	}
+	// The browser of a validated config splits
+	command, commandArgs, _ := splitBrowserTemplate(s.Browser)
+	return &frontend.Browser{
+		Command:     command,
+		CommandArgs: commandArgs,
+	}
+}
+
+// Returns the browser with the given name or nil if there is none.
+func findBrowser(browsers []*browser, name string) *browser {
+	for _, browser := range browsers {
+		if browser != nil && browser.Name == name {
+			return browser
+		}
+	}
+	return nil
+}
+
+func findShortcut(shortcuts []*shortcut, name string) *shortcut {
+	for _, shortcut := range shortcuts {
+		if shortcut.Name == name {
+			return shortcut
+		}
+	}
+	return nil
+}
+
+// findPatched returns the last of the shortcuts the other one patches, or nil if there is none.
+func findPatched(shortcuts []*shortcut, other *shortcut) *shortcut {
+	for _, shortcut := range slices.Backward(shortcuts) {
+		if shortcut.isPatchedBy(other) {
+			return shortcut
+		}
+	}
+	return nil
+}
+
+// key returns the id of the shortcut or, when it has none, its name.
+func (s *shortcut) key() string {
+	if s.Id != "" {
+		return s.Id
+	}
+	return s.Name
+}
+
+// isPatchedBy tells whether the other shortcut patches the shortcut. The other one with an id matches the shortcut of
+// that id or, without one, of that name. The other one without an id matches by name.
+func (s *shortcut) isPatchedBy(other *shortcut) bool {
+	if other.Id != "" {
+		return s.key() == other.Id
+	}
+	return s.Name == other.Name
+}
+
+// hasNameOrId tells whether the shortcut has that name or id.
+func (s *shortcut) hasNameOrId(anchor string) bool {
+	return s.Name == anchor || (s.Id != "" && s.Id == anchor)
+}
+
+func findProfile(profiles []*profile, name string) *profile {
+	for _, profile := range profiles {
+		if profile != nil && profile.Name == name {
+			return profile
+		}
+	}
+	return nil
+}
+
+// Splits the browser command template into the command and its arguments.
+func splitBrowserTemplate(template string) (string, []string, error) {
+	templateParts, err := splitCommandArgs(template)
+	if err != nil || len(templateParts) == 0 {
+		return "", nil, err
+	}
+	return templateParts[0], templateParts[1:], nil
+}
+
+// Splits the command arguments the way a shell would, which fails on unbalanced quotes.
+func splitCommandArgs(commandArgs string) ([]string, error) {
+	if commandArgs == "" {
+		return nil, nil
+	}
+	return shlex.Split(commandArgs)
+}
+
+// Converts the browser to a shortcut, so it can be validated like one.
+func (b *browser) toShortcut() *shortcut {
+	return &shortcut{
+		Name:        b.Name,
+		Command:     b.Command,
+		CommandArgs: b.CommandArgs,
+	}
+}
+
+// Converts the menu item to a shortcut, so it can be validated like one.
+func (m *menuItem) toShortcut(name string) *shortcut {
+	return &shortcut{
+		Name:        name,
+		Command:     m.Command,
+		CommandArgs: m.CommandArgs,
+		Url:         m.Url,
+		Path:        m.Path,
+	}
+}
+
+// Converts the action to a shortcut, so it can be validated like one.
+func (a *action) toShortcut(name string) *shortcut {
+	return &shortcut{
+		Name:        name,
+		Command:     a.Command,
+		CommandArgs: a.CommandArgs,
+		Url:         a.Url,
+		Path:        a.Path,
+	}
+}
+
+// Returns the descriptions of the targets set on the shortcut: Command, URL, Path and Actions.
+func (s *shortcut) targets() []string {
+	var targets []string
+	if s.Command != "" {
+		targets = append(targets, fmt.Sprintf("Command: \"%s\"", s.Command))
+	}
+	if s.Url != "" {
+		targets = append(targets, fmt.Sprintf("URL: \"%s\"", s.Url))
+	}
+	if s.Path != "" {
+		targets = append(targets, fmt.Sprintf("Path: \"%s\"", s.Path))
+	}
+	if len(s.Actions) != 0 {
+		targets = append(targets, fmt.Sprintf("%d Actions", len(s.Actions)))
+	}
+	return targets
 }
 
 // Checks if the shortcut is in patch mode.
@@ -122,5 +543,53 @@ func (s *shortcut) isPatchMode() bool {
 	if s == nil || s.Patch == "" {
 		return false
 	}
-	return s.Patch == patchDelete || s.Patch == patchMerge
+	return s.Patch == patchDelete || s.Patch == patchMerge || s.Patch == patchMove || s.Patch == patchRename
+}
+
+// placements returns where the shortcut is placed once merged.
+func (s *shortcut) placements() []string {
+	var placements []string
+	if s.Before != "" {
+		placements = append(placements, fmt.Sprintf("Before: \"%s\"", s.Before))
+	}
+	if s.After != "" {
+		placements = append(placements, fmt.Sprintf("After: \"%s\"", s.After))
+	}
+	if s.Position != 0 {
+		placements = append(placements, fmt.Sprintf("Position: %d", s.Position))
+	}
+	return placements
+}
+
+// Converts the auto-hide to a frontend.AutoHide with the defaults for the missing values.
+func (a *autoHide) toFrontendAutoHide() *frontend.AutoHide {
+	frontendAutoHide := frontend.NewAutoHide()
+	if a.Enabled != nil {
+		frontendAutoHide.Enabled = *a.Enabled
+	}
+	if delay, err := time.ParseDuration(a.Delay); err == nil {
+		frontendAutoHide.Delay = delay
+	}
+	if a.Mode != "" {
+		frontendAutoHide.Mode = a.Mode
+	}
+	return frontendAutoHide
+}
+
+// Converts the recent section to a frontend.Recent with the defaults for the missing values. The limit defaults to,
+// and is capped at, a single row of icons.
+func (r *recent) toFrontendRecent(iconsPerRow int) *frontend.Recent {
+	frontendRecent := frontend.NewRecent(iconsPerRow)
+	if r.Limit != 0 {
+		frontendRecent.Limit = min(r.Limit, iconsPerRow)
+	}
+	if r.Files != nil {
+		frontendRecent.Files = *r.Files
+	}
+	if r.Launches != nil {
+		frontendRecent.Launches = *r.Launches
+	}
+	frontendRecent.MimeTypes = r.MimeTypes
+	frontendRecent.Applications = r.Applications
+	return frontendRecent
 }
@@ -18,9 +18,11 @@ package yaml
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/test/stub"
 )
 
 func TestNewConfigWithoutShortcuts(t *testing.T) {
@@ -37,31 +39,89 @@ func TestTrim(t *testing.T) {
 		in   *config
 		want *config
 	}{
-		"nil":          {nil, nil},
-		"empty":        {&config{}, &config{}},
-		"title only":   {&config{Title: "  T  "}, &config{Title: "T"}},
+		"nil":        {nil, nil},
+		"empty":      {&config{}, &config{}},
+		"title only": {&config{Title: "  T  "}, &config{Title: "T"}},
+		"profiles": {
+			&config{Profiles: []*profile{nil, {Name: "  ops  ", Title: "  Ops  ", Shortcuts: []*shortcut{nil, {Name: "  Status  "}}}}},
+			&config{Profiles: []*profile{nil, {Name: "ops", Title: "Ops", Shortcuts: []*shortcut{nil, {Name: "Status"}}}}},
+		},
 		"nil shortcut": {&config{Shortcuts: []*shortcut{nil}}, &config{Shortcuts: []*shortcut{nil}}},
+		"policy": {
+			&config{Policy: &policy{LockedShortcuts: []string{"  Terminal  "}, AllowedCommandPrefixes: []string{"  /usr/bin/  "}}},
+			&config{Policy: &policy{LockedShortcuts: []string{"Terminal"}, AllowedCommandPrefixes: []string{"/usr/bin/"}}},
+		},
+		"remote": {
+			&config{Remote: &remote{Url: "  https://example.com/launchee.yml  ", Sha256: "  ABC  ", Timeout: "  3s  "}},
+			&config{Remote: &remote{Url: "https://example.com/launchee.yml", Sha256: "abc", Timeout: "3s"}},
+		},
 		"full": {
 			&config{
-				Title: "  Test Title  ",
+				Title:      "  Test Title  ",
+				UrlSchemes: []string{"  SSH  "},
+				Browsers:   []*browser{nil, {Name: "  Work  ", Command: "  firefox  ", CommandArgs: &commandArgs{Line: "  -P work  "}}},
+				AutoHide:   &autoHide{Delay: "  5s  ", Mode: "  hide  "},
+				Behavior:   &behavior{AfterLaunch: "  quit  "},
+				Sort:       "  mostUsed  ",
+				Recent:     &recent{MimeTypes: []string{"  Text/*  "}, Applications: []string{"  gedit  "}},
 				Shortcuts: []*shortcut{{
 					Name:        "  Name  ",
 					Icon:        "  Icon  ",
 					Command:     "  Command  ",
-					CommandArgs: "  Args  ",
+					CommandArgs: &commandArgs{Line: "  Args  "},
 					Url:         "  Url  ",
-					Patch:       "  Replace  ",
+					Path:        "  Path  ",
+					Browser:     "  Work  ",
+					AfterLaunch: "  hide  ",
+					Actions: []*action{nil, {
+						Command:     "  Command  ",
+						CommandArgs: &commandArgs{Line: "  Args  "},
+						Url:         "  Url  ",
+						Path:        "  Path  ",
+						WaitFor:     &waitFor{Delay: "  2s  ", Port: "  localhost:80  "},
+					}},
+					Menu: []*menuItem{nil, {
+						Label:       "  Label  ",
+						Command:     "  Command  ",
+						CommandArgs: &commandArgs{Line: "  Args  "},
+						Url:         "  Url  ",
+						Path:        "  Path  ",
+					}},
+					Patch: "  Replace  ",
 				}},
 			},
 			&config{
-				Title: "Test Title",
+				Title:      "Test Title",
+				UrlSchemes: []string{"ssh"},
+				Browsers:   []*browser{nil, {Name: "Work", Command: "firefox", CommandArgs: &commandArgs{Line: "-P work"}}},
+				AutoHide:   &autoHide{Delay: "5s", Mode: "hide"},
+				Behavior:   &behavior{AfterLaunch: "quit"},
+				Sort:       "mostUsed",
+				Recent:     &recent{MimeTypes: []string{"text/*"}, Applications: []string{"gedit"}},
 				Shortcuts: []*shortcut{{
 					Name:        "Name",
 					Icon:        "Icon",
 					Command:     "Command",
-					CommandArgs: "Args",
+					CommandArgs: &commandArgs{Line: "Args"},
 					Url:         "Url",
-					Patch:       "Replace",
+					Path:        "Path",
+					Browser:     "Work",
+					AfterLaunch: "hide",
+					Actions: []*action{nil, {
+						Command:     "Command",
+						CommandArgs: &commandArgs{Line: "Args"},
+						Url:         "Url",
+						Path:        "Path",
+						WaitFor:     &waitFor{Delay: "2s", Port: "localhost:80"},
+					}},
+					Menu: []*menuItem{nil, {
+						Label:       "Label",
+						Command:     "Command",
+						CommandArgs: &commandArgs{Line: "Args"},
+						Url:         "Url",
+						Path:        "Path",
+					}},
+					Patch: "Replace",
 				}},
 			},
 		},
@@ -79,12 +139,15 @@ func TestTrim(t *testing.T) {
 }
 
 func TestToFrontendConfig(t *testing.T) {
+	enabled, disabled := true, false
 	defaultUI := frontend.NewUI(0)
 	testTitle := "Test Title"
 	defaultUIOverrideTitleNoShortcuts := frontend.NewUI(0)
 	defaultUIOverrideTitleNoShortcuts.Nav.Title = testTitle
 	defaultUIOverrideTitle := frontend.NewUI(1)
 	defaultUIOverrideTitle.Nav.Title = testTitle
+	defaultUIOverrideTwoShortcuts := frontend.NewUI(2)
+	defaultUIOverrideTwoShortcuts.Nav.Title = testTitle
 
 	testCases := map[string]struct {
 		in   *config
@@ -110,7 +173,7 @@ func TestToFrontendConfig(t *testing.T) {
 					Name:        "Name",
 					Icon:        "",
 					Command:     "Command",
-					CommandArgs: "Arg1 Arg2",
+					CommandArgs: &commandArgs{Line: "Arg1 Arg2"},
 					Url:         "Url",
 					Patch:       "Replace",
 				}},
@@ -118,8 +181,9 @@ func TestToFrontendConfig(t *testing.T) {
 			&frontend.Config{
 				UI: defaultUIOverrideTitle,
 				Shortcuts: []*frontend.Shortcut{{
-					Id:   0,
-					Name: "Name",
+					Id:       0,
+					StableId: "Name",
+					Name:     "Name",
 					Icon: &frontend.Icon{
 						Path:   "",
 						Bytes:  nil,
@@ -128,6 +192,7 @@ func TestToFrontendConfig(t *testing.T) {
 					Command:     "Command",
 					CommandArgs: []string{"Arg1", "Arg2"},
 					Url:         "Url",
+					MenuItems:   frontend.NewBuiltInMenuItems(),
 				}},
 				Valid: true,
 			},
@@ -139,7 +204,7 @@ func TestToFrontendConfig(t *testing.T) {
 					Name:        "Name",
 					Icon:        "../../../build/appicon.png",
 					Command:     "Command",
-					CommandArgs: "Arg1 Arg2",
+					CommandArgs: &commandArgs{Line: "Arg1 Arg2"},
 					Url:         "Url",
 					Patch:       "Replace",
 				}},
@@ -148,17 +213,285 @@ func TestToFrontendConfig(t *testing.T) {
 				UI: defaultUIOverrideTitle,
 				Shortcuts: []*frontend.Shortcut{{
 					Id:          0,
+					StableId:    "Name",
 					Name:        "Name",
 					Icon:        frontend.NewIcon("../../../build/appicon.png"),
 					Command:     "Command",
 					CommandArgs: []string{"Arg1", "Arg2"},
 					Url:         "Url",
+					MenuItems:   frontend.NewBuiltInMenuItems(),
+				}},
+				Valid: true,
+			},
+		},
+		"menu": {
+			&config{
+				Title: testTitle,
+				Shortcuts: []*shortcut{{
+					Name: "Name",
+					Url:  "Url",
+					Menu: []*menuItem{{
+						Label:       "Label",
+						Command:     "Command",
+						CommandArgs: &commandArgs{Line: "Arg1 Arg2"},
+					}, {
+						Label: "Label",
+						Url:   "Url",
+					}},
+				}},
+			},
+			&frontend.Config{
+				UI: defaultUIOverrideTitle,
+				Shortcuts: []*frontend.Shortcut{{
+					Id:       0,
+					StableId: "Name",
+					Name:     "Name",
+					Icon: &frontend.Icon{
+						Base64: "data:image/png;base64,",
+					},
+					Url: "Url",
+					MenuItems: []*frontend.MenuItem{{
+						Label:       "Label",
+						Command:     "Command",
+						CommandArgs: []string{"Arg1", "Arg2"},
+					}, {
+						Label: "Label",
+						Url:   "Url",
+					}},
+				}},
+				Valid: true,
+			},
+		},
+		"path": {
+			&config{
+				Title: testTitle,
+				Shortcuts: []*shortcut{{
+					Name: "Name",
+					Path: "Path",
+					Menu: []*menuItem{{
+						Label: "Label",
+						Path:  "Path",
+					}},
+				}},
+			},
+			&frontend.Config{
+				UI: defaultUIOverrideTitle,
+				Shortcuts: []*frontend.Shortcut{{
+					Id:       0,
+					StableId: "Name",
+					Name:     "Name",
+					Icon: &frontend.Icon{
+						Base64: "data:image/png;base64,",
+					},
+					Path: "Path",
+					MenuItems: []*frontend.MenuItem{{
+						Label: "Label",
+						Path:  "Path",
+					}},
+				}},
+				Valid: true,
+			},
+		},
+		"browser": {
+			&config{
+				Title:    testTitle,
+				Browsers: []*browser{{Name: "Work", Command: "firefox", CommandArgs: &commandArgs{Line: "-P work --new-tab {url}"}}},
+				Shortcuts: []*shortcut{{
+					Name:    "Name",
+					Url:     "Url",
+					Browser: "Work",
+				}, {
+					Id:      "app",
+					Name:    "Template",
+					Url:     "Url",
+					Browser: "chromium --app={url}",
+				}},
+			},
+			&frontend.Config{
+				UI: defaultUIOverrideTwoShortcuts,
+				Shortcuts: []*frontend.Shortcut{{
+					Id:       0,
+					StableId: "Name",
+					Name:     "Name",
+					Icon: &frontend.Icon{
+						Base64: "data:image/png;base64,",
+					},
+					Url:     "Url",
+					Browser: &frontend.Browser{Command: "firefox", CommandArgs: []string{"-P", "work", "--new-tab", "{url}"}},
+				}, {
+					Id:       1,
+					StableId: "app",
+					Name:     "Template",
+					Icon: &frontend.Icon{
+						Base64: "data:image/png;base64,",
+					},
+					Url:     "Url",
+					Browser: &frontend.Browser{Command: "chromium", CommandArgs: []string{"--app={url}"}},
+				}},
+				Valid: true,
+			},
+		},
+		"tray": {
+			&config{
+				Title: testTitle,
+				Tray:  &tray{Enabled: &enabled},
+			},
+			&frontend.Config{
+				UI:    defaultUIOverrideTitleNoShortcuts,
+				Tray:  &frontend.Tray{Enabled: true},
+				Valid: true,
+			},
+		},
+		"auto hide defaults": {
+			&config{
+				Title:    testTitle,
+				AutoHide: &autoHide{Enabled: &enabled},
+			},
+			&frontend.Config{
+				UI:       defaultUIOverrideTitleNoShortcuts,
+				AutoHide: &frontend.AutoHide{Enabled: true, Delay: 3 * time.Second, Mode: frontend.AutoHideModeStrip},
+				Valid:    true,
+			},
+		},
+		"auto hide": {
+			&config{
+				Title:    testTitle,
+				AutoHide: &autoHide{Enabled: &enabled, Delay: "500ms", Mode: frontend.AutoHideModeHide},
+			},
+			&frontend.Config{
+				UI:       defaultUIOverrideTitleNoShortcuts,
+				AutoHide: &frontend.AutoHide{Enabled: true, Delay: 500 * time.Millisecond, Mode: frontend.AutoHideModeHide},
+				Valid:    true,
+			},
+		},
+		"profiles": {
+			&config{
+				Title:    testTitle,
+				Profiles: []*profile{{Name: "dev"}, {Name: "ops"}},
+			},
+			&frontend.Config{
+				UI:       defaultUIOverrideTitleNoShortcuts,
+				Profiles: []string{"dev", "ops"},
+				Valid:    true,
+			},
+		},
+		"skipped": {
+			&config{
+				Title:   testTitle,
+				Skipped: []*frontend.SkippedShortcut{{Name: "Steam", Reason: "OS \"linux\" is not \"windows\""}},
+			},
+			&frontend.Config{
+				UI:               defaultUIOverrideTitleNoShortcuts,
+				SkippedShortcuts: []*frontend.SkippedShortcut{{Name: "Steam", Reason: "OS \"linux\" is not \"windows\""}},
+				Valid:            true,
+			},
+		},
+		"recent disabled": {
+			&config{
+				Title:  testTitle,
+				Recent: &recent{Enabled: &disabled, Limit: 3},
+			},
+			&frontend.Config{
+				UI:    defaultUIOverrideTitleNoShortcuts,
+				Valid: true,
+			},
+		},
+		"recent": {
+			&config{
+				Title:  testTitle,
+				Recent: &recent{Enabled: &enabled, Limit: 3, Files: &disabled, MimeTypes: []string{"text/*"}},
+			},
+			&frontend.Config{
+				UI: func() *frontend.UI {
+					ui := frontend.NewUI(0)
+					ui.Nav.Title = testTitle
+					ui.Recent = frontend.NewRecentContent(3, 5)
+					return ui
+				}(),
+				Recent: &frontend.Recent{Limit: 3, Launches: true, MimeTypes: []string{"text/*"}, Items: []*frontend.RecentItem{}},
+				Valid:  true,
+			},
+		},
+		"after launch": {
+			&config{
+				Title:    testTitle,
+				Behavior: &behavior{AfterLaunch: frontend.AfterLaunchHide},
+				Shortcuts: []*shortcut{{
+					Name:        "Name",
+					Url:         "Url",
+					AfterLaunch: frontend.AfterLaunchQuit,
+				}},
+			},
+			&frontend.Config{
+				UI:       defaultUIOverrideTitle,
+				Behavior: &frontend.Behavior{AfterLaunch: frontend.AfterLaunchHide},
+				Shortcuts: []*frontend.Shortcut{{
+					Id:       0,
+					StableId: "Name",
+					Name:     "Name",
+					Icon: &frontend.Icon{
+						Base64: "data:image/png;base64,",
+					},
+					Url:         "Url",
+					AfterLaunch: frontend.AfterLaunchQuit,
+				}},
+				Valid: true,
+			},
+		},
+		"control api and d-bus": {
+			&config{
+				Title:      testTitle,
+				ControlApi: &enabled,
+				DBus:       &enabled,
+			},
+			&frontend.Config{
+				UI:         defaultUIOverrideTitleNoShortcuts,
+				ControlApi: true,
+				DBus:       true,
+				Valid:      true,
+			},
+		},
+		"actions": {
+			&config{
+				Title: testTitle,
+				Shortcuts: []*shortcut{{
+					Name: "Name",
+					Actions: []*action{{
+						Command:     "Command",
+						CommandArgs: &commandArgs{Line: "Arg1 Arg2"},
+					}, {
+						Url:      "Url",
+						WaitFor:  &waitFor{Exit: true, Delay: "2s", Port: "localhost:80"},
+						Parallel: true,
+					}},
+				}},
+			},
+			&frontend.Config{
+				UI: defaultUIOverrideTitle,
+				Shortcuts: []*frontend.Shortcut{{
+					Id:       0,
+					StableId: "Name",
+					Name:     "Name",
+					Icon: &frontend.Icon{
+						Base64: "data:image/png;base64,",
+					},
+					Actions: []*frontend.Action{{
+						Command:     "Command",
+						CommandArgs: []string{"Arg1", "Arg2"},
+					}, {
+						Url:      "Url",
+						WaitFor:  &frontend.WaitFor{Exit: true, Delay: 2 * time.Second, Port: "localhost:80"},
+						Parallel: true,
+					}},
 				}},
 				Valid: true,
 			},
 		},
 	}
 
+	originalUsageStoreImpl := UsageStoreImpl
+	defer func() { UsageStoreImpl = originalUsageStoreImpl }()
+	UsageStoreImpl = stub.UsageStoreStub{}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
 			got := testCase.in.toFrontendConfig()
@@ -169,24 +502,28 @@ func TestToFrontendConfig(t *testing.T) {
 	}
 }
 
-func TestParseCommandArgs(t *testing.T) {
+func TestSplitCommandArgs(t *testing.T) {
 	testCases := map[string]struct {
-		in   *shortcut
-		want []string
+		in      string
+		want    []string
+		wantErr bool
 	}{
-		"nil":                {nil, nil},
-		"empty":              {&shortcut{CommandArgs: ""}, nil},
-		"2 args":             {&shortcut{CommandArgs: "foo bar"}, []string{"foo", "bar"}},
-		"2 args with spaces": {&shortcut{CommandArgs: "'foo bar' 'baz'"}, []string{"foo bar", "baz"}},
-		"4 args":             {&shortcut{CommandArgs: "-d /tmp/dummy -f foo.txt"}, []string{"-d", "/tmp/dummy", "-f", "foo.txt"}},
-		"4 args with spaces": {&shortcut{CommandArgs: "-s \"space test\" -f foo.txt"}, []string{"-s", "space test", "-f", "foo.txt"}},
+		"empty":            {"", nil, false},
+		"2 args":           {"foo bar", []string{"foo", "bar"}, false},
+		"one arg":          {"foo", []string{"foo"}, false},
+		"args with tabs":   {"foo\tbar", []string{"foo", "bar"}, false},
+		"quoted arg":       {"foo 'bar baz'", []string{"foo", "bar baz"}, false},
+		"unbalanced quote": {"echo -P 'work {url}", nil, true},
 	}
 
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
-			got := testCase.in.parseCommandArgs()
-			if diff := cmp.Diff(testCase.want, got); diff != "" {
-				t.Errorf("parseCommandArgs() = diff -want +got\n%s", diff)
+			got, err := splitCommandArgs(testCase.in)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("splitCommandArgs(%q) = %v, want error %t", testCase.in, err, testCase.wantErr)
+			}
+			if diff := cmp.Diff(testCase.want, got); diff != "" && !testCase.wantErr {
+				t.Errorf("splitCommandArgs(%q) = diff -want +got\n%s", testCase.in, diff)
 			}
 		})
 	}
@@ -0,0 +1,95 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configFileTOML = "launchee.toml"
+	configFileJSON = "launchee.json"
+)
+
+// configFileNames are the supported config files in the order of precedence, when several exist in the same folder.
+var configFileNames = []string{configFileYML, configFileYAML, configFileTOML, configFileJSON}
+
+// parseDocument parses the config file of any supported format into a YAML document, so that all formats share the
+// migrations, the decoding and the validation.
+func parseDocument(configFile string, content []byte) (*yaml.Node, error) {
+	var value any
+	switch strings.ToLower(filepath.Ext(configFile)) {
+	case ".toml":
+		if err := toml.Unmarshal(content, &value); err != nil {
+			return nil, err
+		}
+	case ".json":
+		if err := json.Unmarshal(content, &value); err != nil {
+			return nil, err
+		}
+	default:
+		var document yaml.Node
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return nil, err
+		}
+		return &document, nil
+	}
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&node}}, nil
+}
+
+// encodeDocument encodes the YAML document in the format of the config file. Only YAML keeps the comments.
+func encodeDocument(configFile string, document *yaml.Node) ([]byte, error) {
+	var encoded bytes.Buffer
+	switch strings.ToLower(filepath.Ext(configFile)) {
+	case ".toml":
+		var value map[string]any
+		if err := document.Decode(&value); err != nil {
+			return nil, err
+		}
+		if err := toml.NewEncoder(&encoded).Encode(value); err != nil {
+			return nil, err
+		}
+	case ".json":
+		var value map[string]any
+		if err := document.Decode(&value); err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(&encoded)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(value); err != nil {
+			return nil, err
+		}
+	default:
+		encoder := yaml.NewEncoder(&encoded)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(document); err != nil {
+			return nil, err
+		}
+	}
+	return encoded.Bytes(), nil
+}
@@ -0,0 +1,149 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+const formatsDir = "internal/test/stub/stub_config/formats"
+
+func TestUnmarshalFormats(t *testing.T) {
+	defer chdirBack(t)
+	chdirToRoot(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	testCases := map[string]struct {
+		configFile string
+		profile    string
+	}{
+		"toml":              {configFileTOML, ""},
+		"json":              {configFileJSON, ""},
+		"toml with profile": {configFileTOML, "ops"},
+		"json with profile": {configFileJSON, "ops"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			want, err := UnmarshalCustomConfig(filepath.Join(formatsDir, configFileYML), testCase.profile)
+			if err != nil {
+				t.Fatalf("UnmarshalCustomConfig(%s) = %v", configFileYML, err)
+			}
+			got, err := UnmarshalCustomConfig(filepath.Join(formatsDir, testCase.configFile), testCase.profile)
+			if err != nil {
+				t.Fatalf("UnmarshalCustomConfig(%s) = %v", testCase.configFile, err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("UnmarshalCustomConfig(%s) mismatch (-want +got):\n%s", testCase.configFile, diff)
+			}
+		})
+	}
+}
+
+func TestParseDocument(t *testing.T) {
+	testCases := map[string]struct {
+		configFile string
+		content    string
+		wantTitle  string
+		wantErr    bool
+	}{
+		"yml":          {"launchee.yml", "title: \"Test\"\n", "Test", false},
+		"toml":         {"launchee.toml", "title = \"Test\"\n", "Test", false},
+		"json":         {"launchee.json", "{\"title\": \"Test\"}", "Test", false},
+		"upper case":   {"LAUNCHEE.JSON", "{\"title\": \"Test\"}", "Test", false},
+		"invalid yml":  {"launchee.yml", "title: [", "", true},
+		"invalid toml": {"launchee.toml", "title = ", "", true},
+		"invalid json": {"launchee.json", "{\"title\": ", "", true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			document, err := parseDocument(testCase.configFile, []byte(testCase.content))
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Fatalf("parseDocument() = %v, want error %t", err, testCase.wantErr)
+			}
+			if testCase.wantErr {
+				return
+			}
+			var got config
+			if err := document.Decode(&got); err != nil || got.Title != testCase.wantTitle {
+				t.Errorf("parseDocument() decoded %q, %v, want %q", got.Title, err, testCase.wantTitle)
+			}
+		})
+	}
+}
+
+func TestEncodeDocument(t *testing.T) {
+	testCases := map[string]struct {
+		configFile string
+		want       string
+	}{
+		"yml":  {"launchee.yml", "version: 2\ntitle: Test\n"},
+		"toml": {"launchee.toml", "title = \"Test\"\nversion = 2\n"},
+		"json": {"launchee.json", "{\n  \"title\": \"Test\",\n  \"version\": 2\n}\n"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			document, err := parseDocument("launchee.yml", []byte("version: 2\ntitle: Test\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := encodeDocument(testCase.configFile, document)
+			if err != nil || string(got) != testCase.want {
+				t.Errorf("encodeDocument() = %q, %v, want %q", got, err, testCase.want)
+			}
+		})
+	}
+}
+
+func TestMigrateConfigFileFormats(t *testing.T) {
+	useTestMigrations(t, renameNameToTitle)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	testCases := map[string]struct {
+		configFile string
+		content    string
+	}{
+		"toml": {"launchee.toml", "name = \"Test\"\n"},
+		"json": {"launchee.json", "{\"name\": \"Test\"}\n"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), testCase.configFile)
+			if err := os.WriteFile(configFile, []byte(testCase.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := MigrateConfigFile(configFile); err != nil {
+				t.Fatalf("MigrateConfigFile() = %v", err)
+			}
+			got := unmarshalConfigFile(configFile, nil)
+			if got.err != nil || got.config.Title != "Test" || got.config.Version != 2 {
+				t.Errorf("unmarshalConfigFile() = %+v, %v, want Test of Version 2", got.config, got.err)
+			}
+			if migrated, _ := os.ReadFile(configFile); strings.Contains(string(migrated), "name") {
+				t.Errorf("MigrateConfigFile() wrote %q, want name renamed to title", migrated)
+			}
+		})
+	}
+}
@@ -17,13 +17,19 @@
 package yaml
 
 import (
+	"slices"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
 	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/pkg/errors"
 )
 
 const (
 	patchDelete  = "delete"
 	patchMerge   = "merge"
 	patchReplace = "replace"
+	patchMove    = "move"
+	patchRename  = "rename"
 )
 
 func (yc *config) sanitize() *config {
@@ -35,8 +41,8 @@ func (yc *config) sanitize() *config {
 	sanitizedShortcuts := make([]*shortcut, 0, len(yc.Shortcuts))
 	processed := make(map[string]bool)
 	for _, shortcut := range yc.Shortcuts {
-		if !processed[shortcut.Name] && !shortcut.isPatchMode() {
-			processed[shortcut.Name] = true
+		if !processed[shortcut.key()] && !shortcut.isPatchMode() {
+			processed[shortcut.key()] = true
 			sanitizedShortcuts = append(sanitizedShortcuts, shortcut)
 		}
 	}
@@ -44,59 +50,298 @@ func (yc *config) sanitize() *config {
 	return yc
 }
 
+// merge patches the config with the other one, trusted as the remote config or a profile is.
 func (yc *config) merge(other *config) *config {
+	return yc.mergeLayer(other, false)
+}
+
+// mergeUser patches the config with the user config, which cannot set the policy and is restricted by the one of the
+// config.
+func (yc *config) mergeUser(other *config) *config {
+	if other != nil && other.Policy != nil {
+		lctx.LogInfo("Ignoring Policy of the user config, as only the system config can set it")
+	}
+	return yc.mergeLayer(other, true)
+}
+
+func (yc *config) mergeLayer(other *config, user bool) *config {
 	if yc == nil || other == nil {
 		return yc
 	}
+	var policy *policy
+	if user {
+		policy = yc.Policy
+	}
 	merged := newConfigWithoutShortcuts(yc.Title)
 	if other.Title != "" {
 		merged.Title = other.Title
 	}
-	if len(other.Shortcuts) != 0 {
-		merged.Shortcuts = yc.mergeShortcuts(other)
+	merged.UrlSchemes = slices.Concat(yc.UrlSchemes, other.UrlSchemes)
+	merged.Browsers = yc.mergeBrowsers(policy.allowedBrowsers(other.Browsers))
+	merged.ControlApi = yc.ControlApi
+	if other.ControlApi != nil {
+		merged.ControlApi = other.ControlApi
+	}
+	merged.DBus = yc.DBus
+	if other.DBus != nil {
+		merged.DBus = other.DBus
+	}
+	merged.Tray = yc.Tray
+	if other.Tray != nil && other.Tray.Enabled != nil {
+		merged.Tray = other.Tray
+	}
+	merged.AutoHide = yc.mergeAutoHide(other)
+	merged.Sort = yc.Sort
+	if other.Sort != "" {
+		merged.Sort = other.Sort
+	}
+	merged.Recent = yc.mergeRecent(other)
+	merged.Validation = yc.Validation
+	if other.Validation != "" {
+		merged.Validation = other.Validation
+	}
+	merged.Policy = yc.Policy
+	if !user && other.Policy != nil {
+		merged.Policy = other.Policy
+	}
+	merged.Behavior = yc.Behavior
+	if other.Behavior != nil && other.Behavior.AfterLaunch != "" {
+		merged.Behavior = other.Behavior
+	}
+	otherShortcuts, disallowed := policy.allowedShortcuts(yc.Shortcuts, other.Shortcuts, merged.Browsers, "")
+	if len(otherShortcuts) != 0 {
+		merged.Shortcuts = yc.mergeShortcuts(otherShortcuts)
 	} else {
 		merged.Shortcuts = yc.Shortcuts
 	}
+	otherProfiles, disallowedInProfiles := yc.allowedProfiles(policy, other.Profiles, merged.Browsers)
+	merged.Profiles = yc.mergeProfiles(otherProfiles)
+	merged.Skipped = slices.Concat(yc.Skipped, other.Skipped)
+	merged.Invalid = slices.Concat(yc.Invalid, other.Invalid, disallowed, disallowedInProfiles)
 	return merged
 }
 
-func (yc *config) mergeShortcuts(other *config) []*shortcut {
-	mergedShortcuts := make([]*shortcut, 0, len(yc.Shortcuts)+len(other.Shortcuts))
-	otherShortcuts := toShortcutMapByName(other.Shortcuts)
-	processed := make(map[string]bool)
+// Profiles of the other config replace the ones with the same name.
+func (yc *config) mergeProfiles(otherProfiles []*profile) []*profile {
+	var mergedProfiles []*profile
+	for _, profile := range yc.Profiles {
+		if findProfile(otherProfiles, profile.Name) == nil {
+			mergedProfiles = append(mergedProfiles, profile)
+		}
+	}
+	return append(mergedProfiles, otherProfiles...)
+}
+
+// allowedProfiles drops the shortcuts of the other profiles the policy forbids, as they patch the shortcuts of the
+// config once applied.
+func (yc *config) allowedProfiles(policy *policy, otherProfiles []*profile,
+	browsers []*browser) ([]*profile, []*frontend.InvalidShortcut) {
+	if policy == nil {
+		return otherProfiles, nil
+	}
+	allowedProfiles := make([]*profile, 0, len(otherProfiles))
+	var disallowed []*frontend.InvalidShortcut
+	for _, otherProfile := range otherProfiles {
+		shortcuts, invalid := policy.allowedShortcuts(yc.Shortcuts, otherProfile.Shortcuts, browsers, otherProfile.Name)
+		allowedProfiles = append(allowedProfiles, &profile{Name: otherProfile.Name, Title: otherProfile.Title,
+			Shortcuts: shortcuts})
+		disallowed = append(disallowed, invalid...)
+	}
+	return allowedProfiles, disallowed
+}
+
+// applyProfile patches the config with the title and shortcuts of the profile. No name keeps the config as is.
+func (yc *config) applyProfile(name string) (*config, error) {
+	if name == "" {
+		return yc, nil
+	}
+	var profile *profile
+	if yc != nil {
+		profile = findProfile(yc.Profiles, name)
+	}
+	if profile == nil {
+		return nil, errors.Errorf("Profile \"%s\" is not defined", name)
+	}
+	lctx.LogInfof("Applying \"%s\" Profile", name)
+	return yc.merge(&config{Title: profile.Title, Shortcuts: profile.Shortcuts}), nil
+}
+
+// Browsers of the other config replace the ones with the same name.
+func (yc *config) mergeBrowsers(otherBrowsers []*browser) []*browser {
+	var mergedBrowsers []*browser
+	for _, browser := range yc.Browsers {
+		if findBrowser(otherBrowsers, browser.Name) == nil {
+			mergedBrowsers = append(mergedBrowsers, browser)
+		}
+	}
+	return append(mergedBrowsers, otherBrowsers...)
+}
+
+// mergeAutoHide overrides the auto-hide values set in the other config.
+func (yc *config) mergeAutoHide(other *config) *autoHide {
+	if yc.AutoHide == nil || other.AutoHide == nil {
+		if other.AutoHide != nil {
+			return other.AutoHide
+		}
+		return yc.AutoHide
+	}
+	merged := *yc.AutoHide
+	if other.AutoHide.Enabled != nil {
+		merged.Enabled = other.AutoHide.Enabled
+	}
+	if other.AutoHide.Delay != "" {
+		merged.Delay = other.AutoHide.Delay
+	}
+	if other.AutoHide.Mode != "" {
+		merged.Mode = other.AutoHide.Mode
+	}
+	return &merged
+}
+
+// mergeRecent overrides the recent section values set in the other config.
+func (yc *config) mergeRecent(other *config) *recent {
+	if yc.Recent == nil || other.Recent == nil {
+		if other.Recent != nil {
+			return other.Recent
+		}
+		return yc.Recent
+	}
+	merged := *yc.Recent
+	if other.Recent.Enabled != nil {
+		merged.Enabled = other.Recent.Enabled
+	}
+	if other.Recent.Limit != 0 {
+		merged.Limit = other.Recent.Limit
+	}
+	if other.Recent.Files != nil {
+		merged.Files = other.Recent.Files
+	}
+	if other.Recent.Launches != nil {
+		merged.Launches = other.Recent.Launches
+	}
+	if len(other.Recent.MimeTypes) != 0 {
+		merged.MimeTypes = other.Recent.MimeTypes
+	}
+	if len(other.Recent.Applications) != 0 {
+		merged.Applications = other.Recent.Applications
+	}
+	return &merged
+}
+
+func (yc *config) mergeShortcuts(otherShortcuts []*shortcut) []*shortcut {
+	mergedShortcuts := make([]*shortcut, 0, len(yc.Shortcuts)+len(otherShortcuts))
+	otherShortcutsByName := toShortcutMapByName(otherShortcuts)
+	processed := make(map[*shortcut]bool)
+	// The merged shortcut each other shortcut results in, to place it once all are merged
+	results := make(map[*shortcut]*shortcut)
 
 	lctx.LogInfo("----- Configuration merge started -----")
 	for _, shortcut := range yc.Shortcuts {
-		if otherShortcut, found := otherShortcuts[shortcut.Name]; found {
-			processed[shortcut.Name] = true
-			if otherShortcut.Patch == patchDelete {
+		if otherShortcut := findPatch(otherShortcuts, shortcut); otherShortcut != nil {
+			processed[otherShortcut] = true
+			mergedShortcut := shortcut
+			switch otherShortcut.Patch {
+			case patchDelete:
 				lctx.LogInfof("Deleting %+v", otherShortcut)
 				continue
-			} else if otherShortcut.Patch == patchMerge {
-				mergedShortcut := shortcut.merge(otherShortcut)
+			case patchMerge:
+				mergedShortcut = shortcut.merge(otherShortcut)
 				lctx.LogInfof("Overridding with %+v", mergedShortcut)
-				mergedShortcuts = append(mergedShortcuts, mergedShortcut)
-				continue
+			case patchMove:
+				lctx.LogInfof("Moving %+v", otherShortcut)
+			case patchRename:
+				mergedShortcut = shortcut.renamed(otherShortcut.NewName, yc.Shortcuts, otherShortcutsByName)
+			default:
+				lctx.LogInfof("Replacing with %+v", otherShortcut)
+				mergedShortcut = otherShortcut.withIdOf(shortcut)
 			}
-			lctx.LogInfof("Replacing with %+v", otherShortcut)
-			mergedShortcuts = append(mergedShortcuts, otherShortcut)
+			results[otherShortcut] = mergedShortcut
+			mergedShortcuts = append(mergedShortcuts, mergedShortcut)
 		} else {
 			lctx.LogInfof("Adding %+v", shortcut)
 			mergedShortcuts = append(mergedShortcuts, shortcut)
 		}
 	}
 
-	for _, otherShortcut := range other.Shortcuts {
-		if !processed[otherShortcut.Name] && otherShortcut.Patch != patchDelete && otherShortcut.Patch != patchMerge {
+	added := make(map[string]bool)
+	for _, otherShortcut := range otherShortcuts {
+		if !processed[otherShortcut] && !added[otherShortcut.key()] && !otherShortcut.isPatchMode() {
 			lctx.LogInfof("Adding not processed one %+v", otherShortcut)
-			processed[otherShortcut.Name] = true
+			added[otherShortcut.key()] = true
+			results[otherShortcut] = otherShortcut
 			mergedShortcuts = append(mergedShortcuts, otherShortcut)
 		}
 	}
+
+	for _, otherShortcut := range otherShortcuts {
+		if result, found := results[otherShortcut]; found && len(otherShortcut.placements()) != 0 {
+			mergedShortcuts = placeShortcut(mergedShortcuts, result, otherShortcut)
+		}
+	}
 	lctx.LogInfo("----- Configuration merge finished -----")
 	return mergedShortcuts
 }
 
+// findPatch returns the last of the other shortcuts patching the shortcut, or nil if there is none.
+func findPatch(otherShortcuts []*shortcut, shortcut *shortcut) *shortcut {
+	for _, otherShortcut := range slices.Backward(otherShortcuts) {
+		if shortcut.isPatchedBy(otherShortcut) {
+			return otherShortcut
+		}
+	}
+	return nil
+}
+
+// withIdOf returns the shortcut replacing the replaced one, which keeps its id when the shortcut has none.
+func (s *shortcut) withIdOf(replaced *shortcut) *shortcut {
+	if s.Id != "" || replaced.Id == "" {
+		return s
+	}
+	withId := *s
+	withId.Id = replaced.Id
+	return &withId
+}
+
+// renamed returns a copy of the shortcut with the new name, unless another shortcut already has it. The copy keeps the
+// id, so the renamed shortcut stays the same one.
+func (s *shortcut) renamed(newName string, shortcuts []*shortcut, otherShortcuts map[string]*shortcut) *shortcut {
+	if otherShortcut := otherShortcuts[newName]; findShortcut(shortcuts, newName) != nil ||
+		(otherShortcut != nil && !otherShortcut.isPatchMode()) {
+		lctx.LogErrorf("Not renaming \"%s\" Shortcut, as \"%s\" Shortcut already exists", s.Name, newName)
+		return s
+	}
+	lctx.LogInfof("Renaming \"%s\" Shortcut to \"%s\"", s.Name, newName)
+	renamed := *s
+	renamed.Name = newName
+	return &renamed
+}
+
+// placeShortcut moves the placed shortcut to the position, or before or after the anchor, of the other one patching it.
+func placeShortcut(shortcuts []*shortcut, placed *shortcut, other *shortcut) []*shortcut {
+	from := slices.Index(shortcuts, placed)
+	if from == -1 {
+		return shortcuts
+	}
+	shortcuts = slices.Delete(shortcuts, from, from+1)
+	to := min(other.Position-1, len(shortcuts))
+	if other.Position == 0 {
+		anchor := other.Before
+		if other.After != "" {
+			anchor = other.After
+		}
+		to = slices.IndexFunc(shortcuts, func(shortcut *shortcut) bool { return shortcut.hasNameOrId(anchor) })
+		if to == -1 {
+			lctx.LogErrorf("Not moving \"%s\" Shortcut, as \"%s\" Shortcut does not exist", placed.Name, anchor)
+			return slices.Insert(shortcuts, from, placed)
+		}
+		if other.After != "" {
+			to++
+		}
+	}
+	lctx.LogInfof("Moving \"%s\" Shortcut to position %d", placed.Name, to+1)
+	return slices.Insert(shortcuts, to, placed)
+}
+
 func toShortcutMapByName(shortcuts []*shortcut) map[string]*shortcut {
 	shortcutMap := make(map[string]*shortcut)
 	for _, shortcut := range shortcuts {
@@ -111,15 +356,38 @@ func (s *shortcut) merge(other *shortcut) *shortcut {
 		s.Icon = other.Icon
 	}
 	if other.Command != "" {
+		commandArgs := s.CommandArgs.patched(other.CommandArgs)
+		s.clearTargets()
 		s.Command = other.Command
-		if other.CommandArgs != "" {
-			s.CommandArgs = other.CommandArgs
-		}
-		s.Url = ""
+		s.CommandArgs = commandArgs
+	} else if other.CommandArgs.isPatch() && s.Command != "" {
+		s.CommandArgs = s.CommandArgs.patched(other.CommandArgs)
 	} else if other.Url != "" {
-		s.Command = ""
-		s.CommandArgs = ""
+		s.clearTargets()
 		s.Url = other.Url
+	} else if other.Path != "" {
+		s.clearTargets()
+		s.Path = other.Path
+	} else if len(other.Actions) != 0 {
+		s.clearTargets()
+		s.Actions = other.Actions
+	}
+	if other.Browser != "" {
+		s.Browser = other.Browser
+	}
+	if other.AfterLaunch != "" {
+		s.AfterLaunch = other.AfterLaunch
+	}
+	if len(other.Menu) != 0 {
+		s.Menu = other.Menu
 	}
 	return s
 }
+
+func (s *shortcut) clearTargets() {
+	s.Command = ""
+	s.CommandArgs = nil
+	s.Url = ""
+	s.Path = ""
+	s.Actions = nil
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/internal/config/frontend"
 	"github.com/jdheim/launchee/internal/lctx"
 	"github.com/jdheim/launchee/internal/test/stub"
 )
@@ -35,49 +36,57 @@ func TestSanitize(t *testing.T) {
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 		}, {
 			Name:        "Text Editor",
 			Icon:        "internal/test/stub/stub_config/icons/accessories-text-editor.png",
 			Command:     "echo",
-			CommandArgs: "Text Editor",
+			CommandArgs: &commandArgs{Line: "Text Editor"},
 		}}}, &config{Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 		}, {
 			Name:        "Text Editor",
 			Icon:        "internal/test/stub/stub_config/icons/accessories-text-editor.png",
 			Command:     "echo",
-			CommandArgs: "Text Editor",
+			CommandArgs: &commandArgs{Line: "Text Editor"},
 		}}}},
 		"one duplicate": {&config{Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 		}, {
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 		}, {
 			Name:        "Text Editor",
 			Icon:        "internal/test/stub/stub_config/icons/accessories-text-editor.png",
 			Command:     "echo",
-			CommandArgs: "Text Editor",
+			CommandArgs: &commandArgs{Line: "Text Editor"},
 		}}}, &config{Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 		}, {
 			Name:        "Text Editor",
 			Icon:        "internal/test/stub/stub_config/icons/accessories-text-editor.png",
 			Command:     "echo",
-			CommandArgs: "Text Editor",
+			CommandArgs: &commandArgs{Line: "Text Editor"},
 		}}}},
+		"same name other ids": {
+			&config{Shortcuts: []*shortcut{{Id: "work", Name: "Firefox"}, {Id: "home", Name: "Firefox"}}},
+			&config{Shortcuts: []*shortcut{{Id: "work", Name: "Firefox"}, {Id: "home", Name: "Firefox"}}},
+		},
+		"duplicate id": {
+			&config{Shortcuts: []*shortcut{{Id: "term", Name: "Terminal"}, {Id: "term", Name: "Kitty"}}},
+			&config{Shortcuts: []*shortcut{{Id: "term", Name: "Terminal"}}},
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -91,6 +100,7 @@ func TestSanitize(t *testing.T) {
 }
 
 func TestMerge(t *testing.T) {
+	enabled, disabled := true, false
 	testCases := map[string]struct {
 		input []*config
 		want  *config
@@ -103,41 +113,41 @@ func TestMerge(t *testing.T) {
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 		}}}, {Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Replaced Terminal",
+			CommandArgs: &commandArgs{Line: "Replaced Terminal"},
 		}}}}, &config{Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Replaced Terminal",
+			CommandArgs: &commandArgs{Line: "Replaced Terminal"},
 		}}}},
 		"replace explicitly": {[]*config{{Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 		}}}, {Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/terminal-app.png",
 			Command:     "echo",
-			CommandArgs: "Replaced Terminal",
+			CommandArgs: &commandArgs{Line: "Replaced Terminal"},
 			Patch:       patchReplace,
 		}}}}, &config{Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/terminal-app.png",
 			Command:     "echo",
-			CommandArgs: "Replaced Terminal",
+			CommandArgs: &commandArgs{Line: "Replaced Terminal"},
 			Patch:       patchReplace,
 		}}}},
 		"merge icon": {[]*config{{Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 		}}}, {Shortcuts: []*shortcut{{
 			Name:  "Terminal",
 			Icon:  "internal/test/stub/stub_config/icons/terminal-app.png",
@@ -146,7 +156,7 @@ func TestMerge(t *testing.T) {
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/terminal-app.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 			Patch:       patchMerge,
 		}}}},
 		"merge command": {[]*config{{Shortcuts: []*shortcut{{
@@ -170,13 +180,13 @@ func TestMerge(t *testing.T) {
 		}}}, {Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 			Patch:       patchMerge,
 		}}}}, &config{Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 			Patch:       patchMerge,
 		}}}},
 		"merge command args without command": {[]*config{{Shortcuts: []*shortcut{{
@@ -185,7 +195,7 @@ func TestMerge(t *testing.T) {
 			Url:  "https://example.com",
 		}}}, {Shortcuts: []*shortcut{{
 			Name:        "Terminal",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 			Patch:       patchMerge,
 		}}}}, &config{Shortcuts: []*shortcut{{
 			Name:  "Terminal",
@@ -193,11 +203,58 @@ func TestMerge(t *testing.T) {
 			Url:   "https://example.com",
 			Patch: patchMerge,
 		}}}},
+		"append command args": {[]*config{{Shortcuts: []*shortcut{{
+			Name:        "Firefox",
+			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
+			Command:     "echo",
+			CommandArgs: &commandArgs{Line: "-P work"},
+		}}}, {Shortcuts: []*shortcut{{
+			Name:        "Firefox",
+			CommandArgs: &commandArgs{Prepend: []string{"-v"}, Append: []string{"--private-window"}},
+			Patch:       patchMerge,
+		}}}}, &config{Shortcuts: []*shortcut{{
+			Name:        "Firefox",
+			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
+			Command:     "echo",
+			CommandArgs: &commandArgs{List: []string{"-v", "-P", "work", "--private-window"}},
+			Patch:       patchMerge,
+		}}}},
+		"append command args with command": {[]*config{{Shortcuts: []*shortcut{{
+			Name:        "Firefox",
+			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
+			Command:     "echo",
+			CommandArgs: &commandArgs{List: []string{"-P", "work"}},
+		}}}, {Shortcuts: []*shortcut{{
+			Name:        "Firefox",
+			Command:     "printf",
+			CommandArgs: &commandArgs{Append: []string{"--private-window"}},
+			Patch:       patchMerge,
+		}}}}, &config{Shortcuts: []*shortcut{{
+			Name:        "Firefox",
+			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
+			Command:     "printf",
+			CommandArgs: &commandArgs{List: []string{"-P", "work", "--private-window"}},
+			Patch:       patchMerge,
+		}}}},
+		"append command args without inherited command": {[]*config{{Shortcuts: []*shortcut{{
+			Name: "Firefox",
+			Icon: "internal/test/stub/stub_config/icons/kitty-128.png",
+			Url:  "https://example.com",
+		}}}, {Shortcuts: []*shortcut{{
+			Name:        "Firefox",
+			CommandArgs: &commandArgs{Append: []string{"--private-window"}},
+			Patch:       patchMerge,
+		}}}}, &config{Shortcuts: []*shortcut{{
+			Name:  "Firefox",
+			Icon:  "internal/test/stub/stub_config/icons/kitty-128.png",
+			Url:   "https://example.com",
+			Patch: patchMerge,
+		}}}},
 		"merge url": {[]*config{{Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 		}}}, {Shortcuts: []*shortcut{{
 			Name:  "Terminal",
 			Url:   "https://example.com",
@@ -208,11 +265,180 @@ func TestMerge(t *testing.T) {
 			Url:   "https://example.com",
 			Patch: patchMerge,
 		}}}},
+		"merge path": {[]*config{{Shortcuts: []*shortcut{{
+			Name:        "Terminal",
+			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
+			Command:     "echo",
+			CommandArgs: &commandArgs{Line: "Terminal"},
+		}}}, {Shortcuts: []*shortcut{{
+			Name:  "Terminal",
+			Path:  "/tmp",
+			Patch: patchMerge,
+		}}}}, &config{Shortcuts: []*shortcut{{
+			Name:  "Terminal",
+			Icon:  "internal/test/stub/stub_config/icons/kitty-128.png",
+			Path:  "/tmp",
+			Patch: patchMerge,
+		}}}},
+		"merge url schemes": {[]*config{
+			{UrlSchemes: []string{"ssh"}},
+			{UrlSchemes: []string{"vscode", "mailto"}},
+		}, &config{UrlSchemes: []string{"ssh", "vscode", "mailto"}}},
+		"merge control api": {[]*config{
+			{ControlApi: &enabled},
+			{ControlApi: &disabled},
+		}, &config{ControlApi: &disabled}},
+		"keep control api": {[]*config{
+			{ControlApi: &enabled},
+			{},
+		}, &config{ControlApi: &enabled}},
+		"merge d-bus": {[]*config{
+			{DBus: &disabled},
+			{DBus: &enabled},
+		}, &config{DBus: &enabled}},
+		"merge tray": {[]*config{
+			{Tray: &tray{Enabled: &enabled}},
+			{Tray: &tray{Enabled: &disabled}},
+		}, &config{Tray: &tray{Enabled: &disabled}}},
+		"keep tray": {[]*config{
+			{Tray: &tray{Enabled: &enabled}},
+			{Tray: &tray{}},
+		}, &config{Tray: &tray{Enabled: &enabled}}},
+		"merge auto hide": {[]*config{
+			{AutoHide: &autoHide{Enabled: &enabled, Delay: "5s", Mode: "hide"}},
+			{AutoHide: &autoHide{Enabled: &disabled, Mode: "strip"}},
+		}, &config{AutoHide: &autoHide{Enabled: &disabled, Delay: "5s", Mode: "strip"}}},
+		"add auto hide": {[]*config{
+			{},
+			{AutoHide: &autoHide{Delay: "5s"}},
+		}, &config{AutoHide: &autoHide{Delay: "5s"}}},
+		"merge recent": {[]*config{
+			{Recent: &recent{Enabled: &enabled, Limit: 5, MimeTypes: []string{"text/*"}, Applications: []string{"gedit"}}},
+			{Recent: &recent{Limit: 3, Launches: &disabled, MimeTypes: []string{"image/*"}}},
+		}, &config{Recent: &recent{Enabled: &enabled, Limit: 3, Launches: &disabled, MimeTypes: []string{"image/*"},
+			Applications: []string{"gedit"}}}},
+		"add recent": {[]*config{
+			{},
+			{Recent: &recent{Enabled: &enabled}},
+		}, &config{Recent: &recent{Enabled: &enabled}}},
+		"concat skipped": {[]*config{
+			{Skipped: []*frontend.SkippedShortcut{{Name: "Steam", Reason: "OS \"linux\" is not \"windows\""}}},
+			{Skipped: []*frontend.SkippedShortcut{{Name: "Slack", Reason: "command \"slack\" does not exist"}}},
+		}, &config{Skipped: []*frontend.SkippedShortcut{
+			{Name: "Steam", Reason: "OS \"linux\" is not \"windows\""},
+			{Name: "Slack", Reason: "command \"slack\" does not exist"},
+		}}},
+		"concat invalid": {[]*config{
+			{Invalid: []*frontend.InvalidShortcut{{Name: "Steam", Problem: "Icon of \"Steam\" Shortcut must be set"}}},
+			{Invalid: []*frontend.InvalidShortcut{{Name: "Slack", Profile: "ops", Problem: "Icon of \"Slack\" Shortcut must be set"}}},
+		}, &config{Invalid: []*frontend.InvalidShortcut{
+			{Name: "Steam", Problem: "Icon of \"Steam\" Shortcut must be set"},
+			{Name: "Slack", Profile: "ops", Problem: "Icon of \"Slack\" Shortcut must be set"},
+		}}},
+		"merge validation": {[]*config{
+			{Validation: "strict"},
+			{Validation: "lenient"},
+		}, &config{Validation: "lenient"}},
+		"keep validation": {[]*config{
+			{Validation: "lenient"},
+			{},
+		}, &config{Validation: "lenient"}},
+		"merge sort": {[]*config{
+			{Sort: "recent"},
+			{Sort: "alphabetical"},
+		}, &config{Sort: "alphabetical"}},
+		"keep sort": {[]*config{
+			{Sort: "recent"},
+			{},
+		}, &config{Sort: "recent"}},
+		"merge behavior": {[]*config{
+			{Behavior: &behavior{AfterLaunch: "hide"}},
+			{Behavior: &behavior{AfterLaunch: "quit"}},
+		}, &config{Behavior: &behavior{AfterLaunch: "quit"}}},
+		"keep behavior": {[]*config{
+			{Behavior: &behavior{AfterLaunch: "hide"}},
+			{Behavior: &behavior{}},
+		}, &config{Behavior: &behavior{AfterLaunch: "hide"}}},
+		"merge after launch": {[]*config{{Shortcuts: []*shortcut{{
+			Name: "Dashboard",
+			Url:  "https://example.com",
+		}}}, {Shortcuts: []*shortcut{{
+			Name:        "Dashboard",
+			AfterLaunch: "hide",
+			Patch:       patchMerge,
+		}}}}, &config{Shortcuts: []*shortcut{{
+			Name:        "Dashboard",
+			Url:         "https://example.com",
+			AfterLaunch: "hide",
+			Patch:       patchMerge,
+		}}}},
+		"merge browsers": {[]*config{
+			{Browsers: []*browser{{Name: "Work", Command: "firefox"}, {Name: "Personal", Command: "firefox"}}},
+			{Browsers: []*browser{{Name: "Work", Command: "chromium"}}},
+		}, &config{Browsers: []*browser{{Name: "Personal", Command: "firefox"}, {Name: "Work", Command: "chromium"}}}},
+		"merge browser": {[]*config{{Shortcuts: []*shortcut{{
+			Name: "Dashboard",
+			Url:  "https://example.com",
+		}}}, {Shortcuts: []*shortcut{{
+			Name:    "Dashboard",
+			Browser: "Work",
+			Patch:   patchMerge,
+		}}}}, &config{Shortcuts: []*shortcut{{
+			Name:    "Dashboard",
+			Url:     "https://example.com",
+			Browser: "Work",
+			Patch:   patchMerge,
+		}}}},
+		"merge actions": {[]*config{{Shortcuts: []*shortcut{{
+			Name:        "Terminal",
+			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
+			Command:     "echo",
+			CommandArgs: &commandArgs{Line: "Terminal"},
+		}}}, {Shortcuts: []*shortcut{{
+			Name:    "Terminal",
+			Actions: []*action{{Command: "echo"}, {Url: "https://example.com"}},
+			Patch:   patchMerge,
+		}}}}, &config{Shortcuts: []*shortcut{{
+			Name:    "Terminal",
+			Icon:    "internal/test/stub/stub_config/icons/kitty-128.png",
+			Actions: []*action{{Command: "echo"}, {Url: "https://example.com"}},
+			Patch:   patchMerge,
+		}}}},
+		"merge command over actions": {[]*config{{Shortcuts: []*shortcut{{
+			Name:    "Terminal",
+			Icon:    "internal/test/stub/stub_config/icons/kitty-128.png",
+			Actions: []*action{{Command: "echo"}, {Url: "https://example.com"}},
+		}}}, {Shortcuts: []*shortcut{{
+			Name:    "Terminal",
+			Command: "echo",
+			Patch:   patchMerge,
+		}}}}, &config{Shortcuts: []*shortcut{{
+			Name:    "Terminal",
+			Icon:    "internal/test/stub/stub_config/icons/kitty-128.png",
+			Command: "echo",
+			Patch:   patchMerge,
+		}}}},
+		"merge menu": {[]*config{{Shortcuts: []*shortcut{{
+			Name:    "Terminal",
+			Icon:    "internal/test/stub/stub_config/icons/kitty-128.png",
+			Command: "echo",
+			Menu:    []*menuItem{{Label: "Label", Command: "echo"}},
+		}}}, {Shortcuts: []*shortcut{{
+			Name:  "Terminal",
+			Menu:  []*menuItem{{Label: "Docs", Url: "https://example.com"}},
+			Patch: patchMerge,
+		}}}}, &config{Shortcuts: []*shortcut{{
+			Name:    "Terminal",
+			Icon:    "internal/test/stub/stub_config/icons/kitty-128.png",
+			Command: "echo",
+			Menu:    []*menuItem{{Label: "Docs", Url: "https://example.com"}},
+			Patch:   patchMerge,
+		}}}},
 		"delete": {[]*config{{Shortcuts: []*shortcut{{
 			Name:        "Terminal",
 			Icon:        "internal/test/stub/stub_config/icons/kitty-128.png",
 			Command:     "echo",
-			CommandArgs: "Terminal",
+			CommandArgs: &commandArgs{Line: "Terminal"},
 		}}}, {Shortcuts: []*shortcut{{
 			Name:  "Terminal",
 			Patch: patchDelete,
@@ -229,3 +455,292 @@ func TestMerge(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeShortcutsPlacement(t *testing.T) {
+	newBase := func() *config {
+		return &config{Shortcuts: []*shortcut{
+			{Name: "Terminal", Command: "kitty"},
+			{Name: "Firefox", Url: "https://example.com"},
+			{Name: "Files", Path: "/home"},
+			{Name: "Notes", Command: "gedit"},
+		}}
+	}
+	testCases := map[string]struct {
+		other []*shortcut
+		want  []string
+	}{
+		"move before": {
+			[]*shortcut{{Name: "Notes", Patch: patchMove, Before: "Firefox"}},
+			[]string{"Terminal", "Notes", "Firefox", "Files"},
+		},
+		"move after": {
+			[]*shortcut{{Name: "Terminal", Patch: patchMove, After: "Notes"}},
+			[]string{"Firefox", "Files", "Notes", "Terminal"},
+		},
+		"move to position": {
+			[]*shortcut{{Name: "Files", Patch: patchMove, Position: 1}},
+			[]string{"Files", "Terminal", "Firefox", "Notes"},
+		},
+		"move past the end": {
+			[]*shortcut{{Name: "Terminal", Patch: patchMove, Position: 10}},
+			[]string{"Firefox", "Files", "Notes", "Terminal"},
+		},
+		"move before missing": {
+			[]*shortcut{{Name: "Files", Patch: patchMove, Before: "Slack"}},
+			[]string{"Terminal", "Firefox", "Files", "Notes"},
+		},
+		"move missing": {
+			[]*shortcut{{Name: "Slack", Patch: patchMove, Position: 1}},
+			[]string{"Terminal", "Firefox", "Files", "Notes"},
+		},
+		"moves in order": {
+			[]*shortcut{
+				{Name: "Notes", Patch: patchMove, Position: 1},
+				{Name: "Files", Patch: patchMove, Before: "Notes"},
+			},
+			[]string{"Files", "Notes", "Terminal", "Firefox"},
+		},
+		"rename": {
+			[]*shortcut{{Name: "Firefox", Patch: patchRename, NewName: "Browser"}},
+			[]string{"Terminal", "Browser", "Files", "Notes"},
+		},
+		"rename to existing": {
+			[]*shortcut{{Name: "Firefox", Patch: patchRename, NewName: "Notes"}},
+			[]string{"Terminal", "Firefox", "Files", "Notes"},
+		},
+		"rename to added": {
+			[]*shortcut{
+				{Name: "Firefox", Patch: patchRename, NewName: "Browser"},
+				{Name: "Browser", Url: "https://example.org"},
+			},
+			[]string{"Terminal", "Firefox", "Files", "Notes", "Browser"},
+		},
+		"rename and move": {
+			[]*shortcut{{Name: "Firefox", Patch: patchRename, NewName: "Browser", After: "Notes"}},
+			[]string{"Terminal", "Files", "Notes", "Browser"},
+		},
+		"move before renamed": {
+			[]*shortcut{
+				{Name: "Firefox", Patch: patchRename, NewName: "Browser"},
+				{Name: "Notes", Patch: patchMove, Before: "Browser"},
+			},
+			[]string{"Terminal", "Notes", "Browser", "Files"},
+		},
+		"add at position": {
+			[]*shortcut{{Name: "Slack", Command: "slack", Position: 2}},
+			[]string{"Terminal", "Slack", "Firefox", "Files", "Notes"},
+		},
+		"add after": {
+			[]*shortcut{{Name: "Slack", Command: "slack", After: "Terminal"}},
+			[]string{"Terminal", "Slack", "Firefox", "Files", "Notes"},
+		},
+		"merge and move": {
+			[]*shortcut{{Name: "Notes", Icon: "notes.png", Patch: patchMerge, Position: 1}},
+			[]string{"Notes", "Terminal", "Firefox", "Files"},
+		},
+		"replace and move": {
+			[]*shortcut{{Name: "Terminal", Command: "xterm", Before: "Notes"}},
+			[]string{"Firefox", "Files", "Terminal", "Notes"},
+		},
+	}
+
+	lctx.LoggerImpl = stub.LoggerStub{}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			base := newBase()
+			got := base.merge(&config{Shortcuts: testCase.other})
+			var gotNames []string
+			for _, shortcut := range got.Shortcuts {
+				gotNames = append(gotNames, shortcut.Name)
+			}
+			if diff := cmp.Diff(testCase.want, gotNames); diff != "" {
+				t.Errorf("merge() = diff -want +got\n%s", diff)
+			}
+			if base.Shortcuts[1].Name != "Firefox" {
+				t.Errorf("merge() renamed the base shortcut to %q", base.Shortcuts[1].Name)
+			}
+		})
+	}
+}
+
+func TestMergeShortcutsById(t *testing.T) {
+	newBase := func() *config {
+		return &config{Shortcuts: []*shortcut{
+			{Id: "term", Name: "Terminal", Command: "kitty"},
+			{Id: "web", Name: "Browser", Url: "https://example.com"},
+			{Name: "Files", Path: "/home"},
+		}}
+	}
+	testCases := map[string]struct {
+		other []*shortcut
+		want  []string
+	}{
+		"merge by id": {
+			[]*shortcut{{Id: "web", Name: "Firefox", Icon: "firefox.png", Patch: patchMerge}},
+			[]string{"term: Terminal", "web: Browser", "Files: Files"},
+		},
+		"merge by name": {
+			[]*shortcut{{Name: "Browser", Icon: "firefox.png", Patch: patchMerge}},
+			[]string{"term: Terminal", "web: Browser", "Files: Files"},
+		},
+		"merge by id of the name": {
+			[]*shortcut{{Id: "Files", Name: "Home", Icon: "home.png", Patch: patchMerge}},
+			[]string{"term: Terminal", "web: Browser", "Files: Files"},
+		},
+		"replace keeps id": {
+			[]*shortcut{{Name: "Terminal", Command: "xterm"}},
+			[]string{"term: Terminal", "web: Browser", "Files: Files"},
+		},
+		"replace with new name": {
+			[]*shortcut{{Id: "term", Name: "Console", Command: "xterm"}},
+			[]string{"term: Console", "web: Browser", "Files: Files"},
+		},
+		"rename keeps id": {
+			[]*shortcut{{Id: "web", Name: "Web", Patch: patchRename, NewName: "Firefox"}},
+			[]string{"term: Terminal", "web: Firefox", "Files: Files"},
+		},
+		"delete by id": {
+			[]*shortcut{{Id: "term", Name: "Console", Patch: patchDelete}},
+			[]string{"web: Browser", "Files: Files"},
+		},
+		"other id same name": {
+			[]*shortcut{{Id: "work", Name: "Browser", Url: "https://example.org"}},
+			[]string{"term: Terminal", "web: Browser", "Files: Files", "work: Browser"},
+		},
+		"move after id": {
+			[]*shortcut{{Name: "Files", Patch: patchMove, After: "term"}},
+			[]string{"term: Terminal", "Files: Files", "web: Browser"},
+		},
+	}
+
+	lctx.LoggerImpl = stub.LoggerStub{}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := newBase().merge(&config{Shortcuts: testCase.other})
+			var gotKeys []string
+			for _, shortcut := range got.Shortcuts {
+				gotKeys = append(gotKeys, shortcut.key()+": "+shortcut.Name)
+			}
+			if diff := cmp.Diff(testCase.want, gotKeys); diff != "" {
+				t.Errorf("merge() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeProfiles(t *testing.T) {
+	base := &config{Profiles: []*profile{{Name: "dev", Title: "Dev"}, {Name: "ops", Title: "Ops"}}}
+	other := &config{Profiles: []*profile{{Name: "ops", Title: "Operations"}, {Name: "meetings"}}}
+	want := []*profile{{Name: "dev", Title: "Dev"}, {Name: "ops", Title: "Operations"}, {Name: "meetings"}}
+	if diff := cmp.Diff(want, base.merge(other).Profiles); diff != "" {
+		t.Errorf("merge() = diff -want +got\n%s", diff)
+	}
+}
+
+func TestMergeUser(t *testing.T) {
+	disabled := false
+	newSystem := func() *config {
+		return &config{
+			Browsers: []*browser{{Name: "Work", Command: "firefox"}},
+			Policy:   &policy{AllowUserCommands: &disabled, LockedShortcuts: []string{"Terminal"}},
+			Shortcuts: []*shortcut{
+				{Name: "Terminal", Command: "kitty"},
+				{Name: "Firefox", Url: "https://example.com"},
+			},
+		}
+	}
+	user := &config{
+		Browsers: []*browser{{Name: "Work", Command: "/tmp/evil"}},
+		Policy:   &policy{},
+		Shortcuts: []*shortcut{
+			{Name: "Terminal", Patch: patchDelete},
+			{Name: "Firefox", Patch: patchDelete},
+			{Name: "Weather", Url: "https://www.windy.com"},
+			{Name: "Shell", Command: "sh"},
+		},
+		Profiles: []*profile{{Name: "ops", Shortcuts: []*shortcut{
+			{Name: "Terminal", Command: "sh", Patch: patchReplace},
+			{Name: "Status", Url: "https://status.example.com"},
+		}}},
+	}
+
+	lctx.LoggerImpl = stub.LoggerStub{}
+	got := newSystem().mergeUser(user)
+	want := &config{
+		Browsers: []*browser{{Name: "Work", Command: "firefox"}},
+		Policy:   &policy{AllowUserCommands: &disabled, LockedShortcuts: []string{"Terminal"}},
+		Shortcuts: []*shortcut{
+			{Name: "Terminal", Command: "kitty"},
+			{Name: "Weather", Url: "https://www.windy.com"},
+		},
+		Profiles: []*profile{{Name: "ops", Shortcuts: []*shortcut{{Name: "Status", Url: "https://status.example.com"}}}},
+		Invalid: []*frontend.InvalidShortcut{
+			{Name: "Terminal", Problem: "\"Terminal\" Shortcut is locked by the policy of the system config"},
+			{Name: "Shell", Problem: "Command \"sh\" of \"Shell\" Shortcut is not allowed by the policy of the system config"},
+			{Name: "Terminal", Profile: "ops", Problem: "\"Terminal\" Shortcut is locked by the policy of the system config"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mergeUser() = diff -want +got\n%s", diff)
+	}
+
+	if got := newSystem().merge(user); got.Policy != user.Policy || len(got.Shortcuts) != 2 || len(got.Invalid) != 0 {
+		t.Errorf("merge() = %+v, want the policy and shortcuts of the trusted config", got)
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	newBase := func() *config {
+		return &config{
+			Title: "Launchee",
+			Sort:  "recent",
+			Shortcuts: []*shortcut{
+				{Name: "Terminal", Command: "echo"},
+				{Name: "Firefox", Url: "https://example.com"},
+			},
+			Profiles: []*profile{
+				{Name: "ops", Title: "Operations", Shortcuts: []*shortcut{
+					{Name: "Firefox", Patch: patchDelete},
+					{Name: "Status", Url: "https://status.example.com"},
+				}},
+				{Name: "untitled"},
+			},
+		}
+	}
+	testCases := map[string]struct {
+		in            *config
+		name          string
+		wantTitle     string
+		wantShortcuts []string
+		wantErr       bool
+	}{
+		"no profile": {newBase(), "", "Launchee", []string{"Terminal", "Firefox"}, false},
+		"ops":        {newBase(), "ops", "Operations", []string{"Terminal", "Status"}, false},
+		"untitled":   {newBase(), "untitled", "Launchee", []string{"Terminal", "Firefox"}, false},
+		"undefined":  {newBase(), "meetings", "", nil, true},
+		"nil":        {nil, "ops", "", nil, true},
+	}
+
+	lctx.LoggerImpl = stub.LoggerStub{}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := testCase.in.applyProfile(testCase.name)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Fatalf("applyProfile(%q) = %v, want error %t", testCase.name, err, testCase.wantErr)
+			}
+			if got == nil {
+				return
+			}
+			var gotShortcuts []string
+			for _, shortcut := range got.Shortcuts {
+				gotShortcuts = append(gotShortcuts, shortcut.Name)
+			}
+			if got.Title != testCase.wantTitle || got.Sort != "recent" || len(got.Profiles) != 2 {
+				t.Errorf("applyProfile(%q) = %+v, want title %q with the base settings", testCase.name, got, testCase.wantTitle)
+			}
+			if diff := cmp.Diff(testCase.wantShortcuts, gotShortcuts); diff != "" {
+				t.Errorf("applyProfile(%q) = diff -want +got\n%s", testCase.name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const versionKey = "version"
+
+// migrations upgrade the document of a config from one version to the next one: migrations[0] from 1 to 2 and so on.
+// A config without a version is of version 1.
+var migrations = []func(mapping *yaml.Node) error{
+	migrateTo2,
+}
+
+// migrateTo2 changes nothing, as Version 2 only adds the shortcut ids and the move and rename patches, which are read
+// in configs of any version.
+func migrateTo2(*yaml.Node) error {
+	return nil
+}
+
+// CurrentVersion is the version of the config format, to which older configs are migrated.
+func CurrentVersion() int {
+	return len(migrations) + 1
+}
+
+// migrateDocument upgrades the document in place to the current version and returns the version it was of.
+func migrateDocument(document *yaml.Node) (int, error) {
+	mapping := findMapping(document)
+	if mapping == nil {
+		return CurrentVersion(), nil
+	}
+	version, err := documentVersion(mapping)
+	if err != nil {
+		return 0, err
+	}
+	for i := version - 1; i < len(migrations); i++ {
+		if err = migrations[i](mapping); err != nil {
+			return 0, errors.WithMessagef(err, "Could not migrate Version %d to %d", i+1, i+2)
+		}
+	}
+	setDocumentVersion(mapping, CurrentVersion())
+	return version, nil
+}
+
+func documentVersion(mapping *yaml.Node) (int, error) {
+	versionNode := findValueNode(mapping, versionKey)
+	if versionNode == nil {
+		return 1, nil
+	}
+	version, err := strconv.Atoi(versionNode.Value)
+	if err != nil || version < 1 {
+		return 0, errors.Errorf("Version must be a positive number (got \"%s\")", versionNode.Value)
+	}
+	if version > CurrentVersion() {
+		return 0, errors.Errorf("Version %d is newer than the supported %d, please upgrade Launchee", version, CurrentVersion())
+	}
+	return version, nil
+}
+
+// setDocumentVersion sets the version, as the first key when missing. The comment above the former first key stays at
+// the top of the file.
+func setDocumentVersion(mapping *yaml.Node, version int) {
+	if versionNode := findValueNode(mapping, versionKey); versionNode != nil {
+		versionNode.Value = strconv.Itoa(version)
+		return
+	}
+	versionKeyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: versionKey}
+	if len(mapping.Content) != 0 {
+		versionKeyNode.HeadComment = mapping.Content[0].HeadComment
+		mapping.Content[0].HeadComment = ""
+	}
+	mapping.Content = append([]*yaml.Node{
+		versionKeyNode,
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(version)},
+	}, mapping.Content...)
+}
+
+// findValueNode returns the value of the key in the mapping, or nil when there is no such key.
+func findValueNode(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// MigrateConfigFile rewrites the config file in the current version, keeping the comments of YAML, and backs the
+// original up next to it. It returns the version the file was of and the path of the backup, which is "" when the
+// file was current.
+func MigrateConfigFile(configFile string) (int, string, error) {
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return 0, "", err
+	}
+	document, err := parseDocument(configFile, content)
+	if err != nil {
+		return 0, "", errors.WithMessagef(err, "Could not parse %s", configFile)
+	}
+	mapping := findMapping(document)
+	if mapping == nil {
+		return CurrentVersion(), "", nil
+	}
+	if findValueNode(mapping, versionKey) != nil {
+		if version, err := documentVersion(mapping); err != nil || version == CurrentVersion() {
+			return version, "", errors.WithMessagef(err, "Could not migrate %s", configFile)
+		}
+	}
+	version, err := migrateDocument(document)
+	if err != nil {
+		return 0, "", errors.WithMessagef(err, "Could not migrate %s", configFile)
+	}
+	migrated, err := encodeDocument(configFile, document)
+	if err != nil {
+		return 0, "", err
+	}
+	info, err := os.Stat(configFile)
+	if err != nil {
+		return 0, "", err
+	}
+	backupFile := configFile + ".bak"
+	if err = os.WriteFile(backupFile, content, info.Mode().Perm()); err != nil {
+		return 0, "", errors.WithMessage(err, "Could not back the config up")
+	}
+	if err = os.WriteFile(configFile, migrated, info.Mode().Perm()); err != nil {
+		return 0, "", err
+	}
+	lctx.LogInfof("Migrated %s from Version %d to %d", configFile, version, CurrentVersion())
+	return version, backupFile, nil
+}
+
+// findMapping returns the top-level mapping of the document, or nil when it is empty.
+func findMapping(document *yaml.Node) *yaml.Node {
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	return document.Content[0]
+}
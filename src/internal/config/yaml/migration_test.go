@@ -0,0 +1,144 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+	"gopkg.in/yaml.v3"
+)
+
+// useTestMigrations registers the migration as the only one, from Version 1 to 2.
+func useTestMigrations(t *testing.T, migration func(mapping *yaml.Node) error) {
+	t.Helper()
+	originalMigrations := migrations
+	t.Cleanup(func() { migrations = originalMigrations })
+	migrations = []func(mapping *yaml.Node) error{migration}
+}
+
+// renameNameToTitle is a test migration, as if "title" had been called "name" in Version 1.
+func renameNameToTitle(mapping *yaml.Node) error {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == "name" {
+			mapping.Content[i].Value = "title"
+		}
+	}
+	return nil
+}
+
+func TestMigrateDocument(t *testing.T) {
+	testCases := map[string]struct {
+		in          string
+		migration   func(mapping *yaml.Node) error
+		wantVersion int
+		want        string
+		wantErr     bool
+	}{
+		"unversioned":      {"name: Test\n", renameNameToTitle, 1, "version: 2\ntitle: Test\n", false},
+		"version 1":        {"version: 1\nname: Test\n", renameNameToTitle, 1, "version: 2\ntitle: Test\n", false},
+		"current":          {"version: 2\nname: Test\n", renameNameToTitle, 2, "version: 2\nname: Test\n", false},
+		"newer":            {"version: 3\nname: Test\n", renameNameToTitle, 0, "", true},
+		"zero":             {"version: 0\nname: Test\n", renameNameToTitle, 0, "", true},
+		"not a number":     {"version: one\nname: Test\n", renameNameToTitle, 0, "", true},
+		"failed migration": {"name: Test\n", func(*yaml.Node) error { return errors.ErrUnsupported }, 0, "", true},
+		"empty":            {"", renameNameToTitle, 2, "", false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			useTestMigrations(t, testCase.migration)
+			var document yaml.Node
+			if err := yaml.Unmarshal([]byte(testCase.in), &document); err != nil {
+				t.Fatal(err)
+			}
+			version, err := migrateDocument(&document)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Fatalf("migrateDocument() = %v, want error %t", err, testCase.wantErr)
+			}
+			if version != testCase.wantVersion {
+				t.Errorf("migrateDocument() = %d, want %d", version, testCase.wantVersion)
+			}
+			if testCase.wantErr || testCase.want == "" {
+				return
+			}
+			if got, _ := yaml.Marshal(&document); string(got) != testCase.want {
+				t.Errorf("migrateDocument() migrated to %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestMigrateTo2(t *testing.T) {
+	in := "version: 1\ntitle: Test\nshortcuts:\n  - name: Terminal\n    command: kitty\n"
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(in), &document); err != nil {
+		t.Fatal(err)
+	}
+	version, err := migrateDocument(&document)
+	if err != nil || version != 1 {
+		t.Fatalf("migrateDocument() = %d, %v, want 1", version, err)
+	}
+	want := "version: 2\ntitle: Test\nshortcuts:\n    - name: Terminal\n      command: kitty\n"
+	if got, _ := yaml.Marshal(&document); string(got) != want {
+		t.Errorf("migrateDocument() migrated to %q, want %q", got, want)
+	}
+}
+
+func TestUnmarshalConfigFileMigrates(t *testing.T) {
+	useTestMigrations(t, renameNameToTitle)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	configFile := filepath.Join(t.TempDir(), "launchee.yml")
+	if err := os.WriteFile(configFile, []byte("name: \"Migrated\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got := unmarshalConfigFile(configFile, nil)
+	if got.err != nil || got.config.Title != "Migrated" || got.config.Version != 2 {
+		t.Errorf("unmarshalConfigFile() = %+v, %v, want Migrated of Version 2", got.config, got.err)
+	}
+}
+
+func TestMigrateConfigFile(t *testing.T) {
+	useTestMigrations(t, renameNameToTitle)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	configFile := filepath.Join(t.TempDir(), "launchee.yml")
+	original := "# yaml-language-server: $schema=" + SchemaUrl + "\n\nname: \"Test\" # The title\n"
+	if err := os.WriteFile(configFile, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	version, backupFile, err := MigrateConfigFile(configFile)
+	if err != nil || version != 1 || backupFile != configFile+".bak" {
+		t.Fatalf("MigrateConfigFile() = %d, %q, %v, want 1, %q", version, backupFile, err, configFile+".bak")
+	}
+	want := "# yaml-language-server: $schema=" + SchemaUrl + "\n\nversion: 2\ntitle: \"Test\" # The title\n"
+	if migrated, _ := os.ReadFile(configFile); string(migrated) != want {
+		t.Errorf("MigrateConfigFile() wrote %q, want %q", migrated, want)
+	}
+	if backup, _ := os.ReadFile(backupFile); string(backup) != original {
+		t.Errorf("MigrateConfigFile() backed up %q, want %q", backup, original)
+	}
+
+	version, backupFile, err = MigrateConfigFile(configFile)
+	if err != nil || version != 2 || backupFile != "" {
+		t.Errorf("MigrateConfigFile() of a migrated file = %d, %q, %v, want 2 without a backup", version, backupFile, err)
+	}
+}
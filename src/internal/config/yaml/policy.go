@@ -0,0 +1,149 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/pkg/errors"
+)
+
+// policy restricts what the user config may change, e.g. in a locked-down kiosk. Only the system config, or the remote
+// one it sets, can set it.
+type policy struct {
+	AllowUserCommands      *bool    `yaml:"allowUserCommands"`
+	LockedShortcuts        []string `yaml:"lockedShortcuts"`
+	AllowedCommandPrefixes []string `yaml:"allowedCommandPrefixes"`
+}
+
+// allowsCommand tells whether the user config may run the command. No policy allows any command. With prefixes, the
+// command must be an absolute path, which, once cleaned, is one of them or under one of them as a dir.
+func (p *policy) allowsCommand(command string) bool {
+	if p == nil {
+		return true
+	}
+	if p.AllowUserCommands != nil && !*p.AllowUserCommands {
+		return false
+	}
+	if len(p.AllowedCommandPrefixes) == 0 {
+		return true
+	}
+	if !filepath.IsAbs(command) {
+		return false
+	}
+	command = filepath.Clean(command)
+	return slices.ContainsFunc(p.AllowedCommandPrefixes, func(prefix string) bool {
+		prefix = filepath.Clean(prefix)
+		return command == prefix ||
+			strings.HasPrefix(command, strings.TrimSuffix(prefix, string(filepath.Separator))+string(filepath.Separator))
+	})
+}
+
+// violation returns why the policy forbids the user shortcut to patch the system one, which is nil when the user
+// shortcut is a new one. The browsers are the merged ones, whose commands the policy has already allowed.
+func (p *policy) violation(systemShortcut *shortcut, userShortcut *shortcut, browsers []*browser) error {
+	if p == nil {
+		return nil
+	}
+	if systemShortcut != nil && slices.ContainsFunc(p.LockedShortcuts, systemShortcut.hasNameOrId) {
+		return errors.Errorf("\"%s\" Shortcut is locked by the policy of the system config", systemShortcut.Name)
+	}
+	for _, command := range userShortcut.userCommands(systemShortcut, browsers) {
+		if !p.allowsCommand(command) {
+			return errors.Errorf("Command \"%s\" of \"%s\" Shortcut is not allowed by the policy of the system config",
+				command, userShortcut.Name)
+		}
+	}
+	return nil
+}
+
+// userCommands returns the commands the user shortcut runs, including the one of the system shortcut whose arguments
+// it patches and the one of its browser command template.
+func (s *shortcut) userCommands(systemShortcut *shortcut, browsers []*browser) []string {
+	if s.Patch == patchDelete {
+		return nil
+	}
+	var commands []string
+	if s.Command != "" {
+		commands = append(commands, s.Command)
+	} else if s.CommandArgs != nil && s.Patch == patchMerge && systemShortcut != nil && systemShortcut.Command != "" {
+		commands = append(commands, systemShortcut.Command)
+	}
+	if s.Browser != "" && findBrowser(browsers, s.Browser) == nil {
+		command, _, err := splitBrowserTemplate(s.Browser)
+		if err != nil {
+			// The whole template is checked, as the validation rejects it only after the merge
+			command = s.Browser
+		}
+		if command != "" {
+			commands = append(commands, command)
+		}
+	}
+	for _, action := range s.Actions {
+		if action != nil && action.Command != "" {
+			commands = append(commands, action.Command)
+		}
+	}
+	for _, menuItem := range s.Menu {
+		if menuItem != nil && menuItem.Command != "" {
+			commands = append(commands, menuItem.Command)
+		}
+	}
+	return commands
+}
+
+// allowedShortcuts drops the user shortcuts the policy forbids to patch the system ones, returning them as invalid.
+func (p *policy) allowedShortcuts(systemShortcuts []*shortcut, userShortcuts []*shortcut, browsers []*browser,
+	profileName string) ([]*shortcut, []*frontend.InvalidShortcut) {
+	if p == nil {
+		return userShortcuts, nil
+	}
+	allowed := make([]*shortcut, 0, len(userShortcuts))
+	var invalid []*frontend.InvalidShortcut
+	for _, userShortcut := range userShortcuts {
+		if err := p.violation(findPatched(systemShortcuts, userShortcut), userShortcut, browsers); err != nil {
+			lctx.LogErrorf("Skipping \"%s\" Shortcut of the user config: %v", userShortcut.Name, err)
+			invalid = append(invalid, &frontend.InvalidShortcut{
+				Name:    userShortcut.Name,
+				Profile: profileName,
+				Problem: err.Error(),
+			})
+			continue
+		}
+		allowed = append(allowed, userShortcut)
+	}
+	return allowed, invalid
+}
+
+// allowedBrowsers drops the user browsers whose command the policy forbids.
+func (p *policy) allowedBrowsers(userBrowsers []*browser) []*browser {
+	if p == nil {
+		return userBrowsers
+	}
+	return slices.DeleteFunc(slices.Clone(userBrowsers), func(browser *browser) bool {
+		if p.allowsCommand(browser.Command) {
+			return false
+		}
+		lctx.LogErrorf("Skipping \"%s\" Browser of the user config: Command \"%s\" is not allowed by the policy of "+
+			"the system config", browser.Name, browser.Command)
+		return true
+	})
+}
@@ -0,0 +1,154 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+func TestPolicyAllowsCommand(t *testing.T) {
+	enabled, disabled := true, false
+	testCases := map[string]struct {
+		policy  *policy
+		command string
+		want    bool
+	}{
+		"no policy":         {nil, "kitty", true},
+		"empty policy":      {&policy{}, "kitty", true},
+		"user commands":     {&policy{AllowUserCommands: &enabled}, "kitty", true},
+		"no user commands":  {&policy{AllowUserCommands: &disabled}, "/usr/bin/kitty", false},
+		"allowed prefix":    {&policy{AllowedCommandPrefixes: []string{"/opt/", "/usr/bin/"}}, "/usr/bin/kitty", true},
+		"allowed command":   {&policy{AllowedCommandPrefixes: []string{"/usr/bin/flatpak"}}, "/usr/bin/flatpak", true},
+		"disallowed prefix": {&policy{AllowedCommandPrefixes: []string{"/usr/bin/"}}, "/tmp/kitty", false},
+		"relative command":  {&policy{AllowedCommandPrefixes: []string{"/usr/bin/"}}, "kitty", false},
+		"dot dot":           {&policy{AllowedCommandPrefixes: []string{"/usr/bin/"}}, "/usr/bin/../../tmp/evil", false},
+		"no dir boundary":   {&policy{AllowedCommandPrefixes: []string{"/usr/bin"}}, "/usr/binevil", false},
+		"prefixes, but no user commands": {
+			&policy{AllowUserCommands: &disabled, AllowedCommandPrefixes: []string{"/usr/bin/"}}, "/usr/bin/kitty", false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := testCase.policy.allowsCommand(testCase.command); got != testCase.want {
+				t.Errorf("allowsCommand(%q) = %t, want %t", testCase.command, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestPolicyViolation(t *testing.T) {
+	disabled := false
+	terminal := &shortcut{Name: "Terminal", Command: "/usr/bin/kitty"}
+	noCommands := &policy{AllowUserCommands: &disabled, LockedShortcuts: []string{"Terminal"}}
+	browsers := []*browser{{Name: "Work", Command: "/usr/bin/firefox"}}
+	testCases := map[string]struct {
+		policy         *policy
+		systemShortcut *shortcut
+		userShortcut   *shortcut
+		wantErr        bool
+	}{
+		"no policy":       {nil, terminal, &shortcut{Name: "Terminal", Patch: patchDelete}, false},
+		"locked delete":   {noCommands, terminal, &shortcut{Name: "Terminal", Patch: patchDelete}, true},
+		"locked merge":    {noCommands, terminal, &shortcut{Name: "Terminal", Icon: "icon.png", Patch: patchMerge}, true},
+		"unlocked delete": {noCommands, &shortcut{Name: "Firefox"}, &shortcut{Name: "Firefox", Patch: patchDelete}, false},
+		"locked by id": {
+			&policy{LockedShortcuts: []string{"term"}}, &shortcut{Id: "term", Name: "Terminal"},
+			&shortcut{Id: "term", Name: "Console", Patch: patchDelete}, true,
+		},
+		"new url":           {noCommands, nil, &shortcut{Name: "Weather", Url: "https://www.windy.com"}, false},
+		"new command":       {noCommands, nil, &shortcut{Name: "Shell", Command: "sh"}, true},
+		"action command":    {noCommands, nil, &shortcut{Name: "Dev", Actions: []*action{{Command: "sh"}}}, true},
+		"menu item command": {noCommands, nil, &shortcut{Name: "Docs", Menu: []*menuItem{{Label: "Run", Command: "sh"}}}, true},
+		"args of system command": {
+			&policy{AllowUserCommands: &disabled}, terminal,
+			&shortcut{Name: "Terminal", CommandArgs: &commandArgs{Append: []string{"-e", "sh"}}, Patch: patchMerge}, true,
+		},
+		"allowed prefix": {
+			&policy{AllowedCommandPrefixes: []string{"/usr/bin/"}}, nil, &shortcut{Name: "Top", Command: "/usr/bin/top"}, false,
+		},
+		"disallowed prefix": {
+			&policy{AllowedCommandPrefixes: []string{"/usr/bin/"}}, nil, &shortcut{Name: "Evil", Command: "/tmp/evil"}, true,
+		},
+		"named browser": {
+			noCommands, nil, &shortcut{Name: "Weather", Url: "https://www.windy.com", Browser: "Work"}, false,
+		},
+		"browser template": {
+			noCommands, nil, &shortcut{Name: "Weather", Url: "https://www.windy.com", Browser: "/tmp/evil %s"}, true,
+		},
+		"unbalanced quote": {
+			&policy{AllowedCommandPrefixes: []string{"/usr/bin/"}}, nil,
+			&shortcut{Name: "Weather", Url: "https://www.windy.com", Browser: "'/tmp/evil"}, true,
+		},
+		"browser of merge": {
+			&policy{AllowUserCommands: &disabled}, &shortcut{Name: "Weather", Url: "https://www.windy.com"},
+			&shortcut{Name: "Weather", Browser: "/tmp/evil", Patch: patchMerge}, true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.policy.violation(testCase.systemShortcut, testCase.userShortcut, browsers)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("violation() = %v, want error %t", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyAllowedBrowsers(t *testing.T) {
+	lctx.LoggerImpl = stub.LoggerStub{}
+	browsers := []*browser{{Name: "Work", Command: "/usr/bin/firefox"}, {Name: "Evil", Command: "/tmp/evil"}}
+	got := (&policy{AllowedCommandPrefixes: []string{"/usr/bin/"}}).allowedBrowsers(browsers)
+	want := []*browser{{Name: "Work", Command: "/usr/bin/firefox"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("allowedBrowsers() = diff -want +got\n%s", diff)
+	}
+	if len(browsers) != 2 {
+		t.Errorf("allowedBrowsers() modified the user browsers to %v", browsers)
+	}
+}
+
+func TestPolicyAllowedShortcuts(t *testing.T) {
+	lctx.LoggerImpl = stub.LoggerStub{}
+	systemShortcuts := []*shortcut{{Name: "Terminal", Command: "kitty"}, {Name: "Firefox", Url: "https://example.com"}}
+	userShortcuts := []*shortcut{
+		{Name: "Terminal", Patch: patchDelete},
+		{Name: "Firefox", Patch: patchDelete},
+		{Name: "Shell", Command: "sh"},
+	}
+	disabled := false
+	policy := &policy{AllowUserCommands: &disabled, LockedShortcuts: []string{"Terminal"}}
+
+	got, gotInvalid := policy.allowedShortcuts(systemShortcuts, userShortcuts, nil, "ops")
+	if diff := cmp.Diff([]*shortcut{{Name: "Firefox", Patch: patchDelete}}, got); diff != "" {
+		t.Errorf("allowedShortcuts() = diff -want +got\n%s", diff)
+	}
+	wantInvalid := []*frontend.InvalidShortcut{
+		{Name: "Terminal", Profile: "ops", Problem: "\"Terminal\" Shortcut is locked by the policy of the system config"},
+		{Name: "Shell", Profile: "ops",
+			Problem: "Command \"sh\" of \"Shell\" Shortcut is not allowed by the policy of the system config"},
+	}
+	if diff := cmp.Diff(wantInvalid, gotInvalid); diff != "" {
+		t.Errorf("allowedShortcuts() invalid = diff -want +got\n%s", diff)
+	}
+}
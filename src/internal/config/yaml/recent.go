@@ -0,0 +1,103 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/xbel"
+)
+
+// RecentFiles provides the recently used files of the desktop.
+type RecentFiles interface {
+	Load() ([]*xbel.Bookmark, error)
+}
+
+var RecentFilesImpl RecentFiles = xbel.NewReader(xbel.Path)
+
+type recentItem struct {
+	item     *frontend.RecentItem
+	lastUsed time.Time
+}
+
+// LoadRecentItems returns the most recently used files and launched shortcuts of the recent section, the most
+// recent first.
+func LoadRecentItems(recent *frontend.Recent, shortcuts []*frontend.Shortcut) []*frontend.RecentItem {
+	if recent == nil {
+		return nil
+	}
+	var recentItems []*recentItem
+	if recent.Files {
+		recentItems = append(recentItems, loadRecentFiles(recent)...)
+	}
+	if recent.Launches {
+		recentItems = append(recentItems, loadRecentLaunches(shortcuts)...)
+	}
+	slices.SortStableFunc(recentItems, func(a, b *recentItem) int {
+		return b.lastUsed.Compare(a.lastUsed)
+	})
+	items := make([]*frontend.RecentItem, 0, min(len(recentItems), recent.Limit))
+	for _, recentItem := range recentItems[:min(len(recentItems), recent.Limit)] {
+		items = append(items, recentItem.item)
+	}
+	return items
+}
+
+// loadRecentFiles returns the existing local files matching the MIME types and applications of the recent section.
+func loadRecentFiles(recent *frontend.Recent) []*recentItem {
+	bookmarks, err := RecentFilesImpl.Load()
+	if err != nil {
+		lctx.LogErrorf("Recent files are not listed: %v", err)
+		return nil
+	}
+	var recentItems []*recentItem
+	for _, bookmark := range bookmarks {
+		path, local := bookmark.LocalPath()
+		if !local || !bookmark.MatchesMimeType(recent.MimeTypes) || !bookmark.UsedByAny(recent.Applications) ||
+			!fileExists(path) {
+			continue
+		}
+		recentItems = append(recentItems, &recentItem{
+			item:     &frontend.RecentItem{Name: filepath.Base(path), Path: path},
+			lastUsed: bookmark.LastUsed(),
+		})
+	}
+	return recentItems
+}
+
+// loadRecentLaunches returns the shortcuts that were launched at least once.
+func loadRecentLaunches(shortcuts []*frontend.Shortcut) []*recentItem {
+	usage, err := UsageStoreImpl.Load()
+	if err != nil {
+		lctx.LogErrorf("Recent launches are not listed: %v", err)
+		return nil
+	}
+	var recentItems []*recentItem
+	for _, shortcut := range shortcuts {
+		if shortcutUsage := usageOf(usage, shortcut.StableId, shortcut.Name); shortcutUsage != nil && shortcutUsage.Launches != 0 {
+			recentItems = append(recentItems, &recentItem{
+				item:     &frontend.RecentItem{Name: shortcut.Name, ShortcutId: shortcut.Id},
+				lastUsed: shortcutUsage.LastLaunch,
+			})
+		}
+	}
+	return recentItems
+}
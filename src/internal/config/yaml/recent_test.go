@@ -0,0 +1,112 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/test/stub"
+	"github.com/jdheim/launchee/internal/xbel"
+)
+
+const recentlyUsedBookmark = `
+  <bookmark href="file://%s" added="%s" modified="%[2]s" visited="%[2]s">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="%s"/>
+        <bookmark:applications>
+          <bookmark:application name="%s" exec="&apos;%[4]s %%u&apos;" modified="%[2]s" count="1"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>`
+
+func TestLoadRecentItems(t *testing.T) {
+	dir := t.TempDir()
+	notes := filepath.Join(dir, "notes.txt")
+	photo := filepath.Join(dir, "photo.png")
+	for _, file := range []string{notes, photo} {
+		if err := os.WriteFile(file, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	recentlyUsed := `<?xml version="1.0" encoding="UTF-8"?>
+<xbel version="1.0" xmlns:bookmark="http://www.freedesktop.org/standards/desktop-bookmarks" xmlns:mime="http://www.freedesktop.org/standards/shared-mime-info">` +
+		fmt.Sprintf(recentlyUsedBookmark, notes, "2025-01-03T00:00:00Z", "text/plain", "gedit") +
+		fmt.Sprintf(recentlyUsedBookmark, photo, "2025-01-01T12:00:00Z", "image/png", "eog") +
+		fmt.Sprintf(recentlyUsedBookmark, filepath.Join(dir, "missing.txt"), "2025-01-04T00:00:00Z", "text/plain", "gedit") +
+		`</xbel>`
+	xbelPath := filepath.Join(dir, "recently-used.xbel")
+	if err := os.WriteFile(xbelPath, []byte(recentlyUsed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	shortcuts := []*frontend.Shortcut{{Id: 0, Name: "Firefox"}, {Id: 1, Name: "Terminal"}, {Id: 2, Name: "Editor"}}
+	notesItem := &frontend.RecentItem{Name: "notes.txt", Path: notes}
+	photoItem := &frontend.RecentItem{Name: "photo.png", Path: photo}
+	firefoxItem := &frontend.RecentItem{Name: "Firefox", ShortcutId: 0}
+	terminalItem := &frontend.RecentItem{Name: "Terminal", ShortcutId: 1}
+
+	testCases := map[string]struct {
+		recent     *frontend.Recent
+		usageStore UsageStore
+		want       []*frontend.RecentItem
+	}{
+		"nil":             {nil, stub.UsageStoreStub{}, nil},
+		"all":             {frontend.NewRecent(5), stub.UsageStoreStub{}, []*frontend.RecentItem{notesItem, terminalItem, photoItem, firefoxItem}},
+		"limit":           {frontend.NewRecent(2), stub.UsageStoreStub{}, []*frontend.RecentItem{notesItem, terminalItem}},
+		"files":           {&frontend.Recent{Limit: 5, Files: true}, stub.UsageStoreStub{}, []*frontend.RecentItem{notesItem, photoItem}},
+		"launches":        {&frontend.Recent{Limit: 5, Launches: true}, stub.UsageStoreStub{}, []*frontend.RecentItem{terminalItem, firefoxItem}},
+		"mime types":      {&frontend.Recent{Limit: 5, Files: true, MimeTypes: []string{"image/*"}}, stub.UsageStoreStub{}, []*frontend.RecentItem{photoItem}},
+		"applications":    {&frontend.Recent{Limit: 5, Files: true, Applications: []string{"gedit"}}, stub.UsageStoreStub{}, []*frontend.RecentItem{notesItem}},
+		"corrupted stats": {frontend.NewRecent(5), stub.UsageStoreCorruptedStub{}, []*frontend.RecentItem{notesItem, photoItem}},
+	}
+
+	originalRecentFilesImpl, originalUsageStoreImpl := RecentFilesImpl, UsageStoreImpl
+	defer func() { RecentFilesImpl, UsageStoreImpl = originalRecentFilesImpl, originalUsageStoreImpl }()
+	RecentFilesImpl = xbel.NewReader(func() string { return xbelPath })
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			UsageStoreImpl = testCase.usageStore
+			got := LoadRecentItems(testCase.recent, shortcuts)
+			if diff := cmp.Diff(testCase.want, got); diff != "" {
+				t.Errorf("LoadRecentItems() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadRecentItemsCorruptedFiles(t *testing.T) {
+	xbelPath := filepath.Join(t.TempDir(), "recently-used.xbel")
+	if err := os.WriteFile(xbelPath, []byte("<xbel><bookmark"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalRecentFilesImpl, originalUsageStoreImpl := RecentFilesImpl, UsageStoreImpl
+	defer func() { RecentFilesImpl, UsageStoreImpl = originalRecentFilesImpl, originalUsageStoreImpl }()
+	RecentFilesImpl = xbel.NewReader(func() string { return xbelPath })
+	UsageStoreImpl = stub.UsageStoreStub{}
+
+	got := LoadRecentItems(frontend.NewRecent(5), []*frontend.Shortcut{{Id: 3, Name: "Terminal"}})
+	want := []*frontend.RecentItem{{Name: "Terminal", ShortcutId: 3}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LoadRecentItems() = diff -want +got\n%s", diff)
+	}
+}
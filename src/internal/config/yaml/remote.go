@@ -0,0 +1,269 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRemoteTimeout = 5 * time.Second
+	maxRemoteConfigSize  = 1 << 20 // 1 MB
+	remoteCacheDir       = "remote"
+)
+
+var userCacheDir = os.UserCacheDir
+
+// remoteCache is the last fetched copy of a remote config, with the validators the server sent to revalidate it.
+type remoteCache struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	content      []byte
+	signature    []byte
+}
+
+// unmarshalRemoteConfig fetches and unmarshals the remote config set by the config. No remote unmarshals to no config.
+// A verifier with a public key requires the remote config to be signed.
+func unmarshalRemoteConfig(config *config, verifier *signatureVerifier) *unmarshalResult {
+	if config == nil || config.Remote == nil {
+		return &unmarshalResult{nil, nil}
+	}
+	if err := validateRemote(config); err != nil {
+		return &unmarshalResult{nil, err}
+	}
+	source := remoteSource(config.Remote.Url)
+	content, err := readRemoteConfig(config.Remote, source, verifier)
+	if err != nil {
+		return &unmarshalResult{nil, errors.WithMessagef(err, "Could not fetch %s", source)}
+	}
+	result := unmarshalConfigContent(source, content)
+	if result.config != nil && result.config.Remote != nil {
+		lctx.LogInfof("Ignoring Remote of %s, as a remote config cannot set another one", source)
+		result.config.Remote = nil
+	}
+	if result.config != nil && result.config.Policy != nil && !isRemoteVerified(config.Remote, verifier) {
+		lctx.LogInfof("Ignoring Policy of %s, as only a remote config verified by its SHA-256 or signature can set it",
+			source)
+		result.config.Policy = nil
+	}
+	return result
+}
+
+// isRemoteVerified tells whether the remote config is verified by its checksum or signature, so neither the server
+// nor the cached copy, which the user can write, can replace it.
+func isRemoteVerified(remote *remote, verifier *signatureVerifier) bool {
+	return remote.Sha256 != "" || verifier.requiresSignature()
+}
+
+// remoteSource is the URL without credentials, query and fragment, which names the remote config in messages and tells
+// its format by the extension.
+func remoteSource(remoteUrl string) string {
+	parsedUrl, err := url.Parse(remoteUrl)
+	if err != nil {
+		return remoteUrl
+	}
+	parsedUrl.User = nil
+	parsedUrl.RawQuery = ""
+	parsedUrl.Fragment = ""
+	return parsedUrl.String()
+}
+
+// readRemoteConfig fetches the remote config, unless the cached copy is still current, and falls back to the cached
+// copy when the server cannot be reached. The cached copy is verified on every read, as the user can write it, and is
+// fetched again when it does not pass. An unverified remote config is not cached, as nothing could verify the copy.
+func readRemoteConfig(remote *remote, source string, verifier *signatureVerifier) ([]byte, error) {
+	verify := func(cache *remoteCache) error {
+		if err := verifyRemoteChecksum(remote, cache.content); err != nil {
+			return err
+		}
+		return verifier.verify(source, cache.content, cache.signature)
+	}
+	cacheFile := ""
+	if isRemoteVerified(remote, verifier) {
+		cacheFile = remoteCacheFile(remote.Url)
+	}
+	cache := readRemoteCache(cacheFile)
+	if cache != nil {
+		if err := verify(cache); err != nil {
+			lctx.LogErrorf("Ignoring the cached copy of %s: %v", source, err)
+			cache = nil
+		}
+	}
+	fetched, err := fetchRemoteConfig(remote, cache, verifier.requiresSignature())
+	if err == nil && fetched == nil {
+		lctx.LogInfof("%s is not modified, using the cached copy", source)
+		return cache.content, nil
+	}
+	if err == nil {
+		err = verify(fetched)
+	}
+	if err == nil {
+		writeRemoteCache(cacheFile, fetched)
+		return fetched.content, nil
+	}
+	if cache == nil {
+		return nil, err
+	}
+	lctx.LogErrorf("Could not fetch %s, using the cached copy: %v", source, err)
+	return cache.content, nil
+}
+
+// fetchRemoteConfig requests the remote config, conditionally when cached, with its signature when required. Not
+// modified fetches no config.
+func fetchRemoteConfig(remote *remote, cache *remoteCache, withSignature bool) (*remoteCache, error) {
+	timeout := defaultRemoteTimeout
+	if remote.Timeout != "" {
+		timeout, _ = time.ParseDuration(remote.Timeout)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	fetched, err := fetchRemoteFile(ctx, remote.Url, cache)
+	if err != nil || fetched == nil || !withSignature {
+		return fetched, err
+	}
+	signature, err := fetchRemoteFile(ctx, remoteSignatureUrl(remote.Url), nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not fetch the signature")
+	}
+	fetched.signature = signature.content
+	return fetched, nil
+}
+
+// fetchRemoteFile requests the file, conditionally when cached. Not modified fetches no file.
+func fetchRemoteFile(ctx context.Context, fileUrl string, cache *remoteCache) (*remoteCache, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fileUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		if cache.ETag != "" {
+			request.Header.Set("If-None-Match", cache.ETag)
+		}
+		if cache.LastModified != "" {
+			request.Header.Set("If-Modified-Since", cache.LastModified)
+		}
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	switch response.StatusCode {
+	case http.StatusNotModified:
+		if cache != nil {
+			return nil, nil
+		}
+	case http.StatusOK:
+		content, err := io.ReadAll(io.LimitReader(response.Body, maxRemoteConfigSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(content) > maxRemoteConfigSize {
+			return nil, errors.New("Remote config is larger than 1 MB")
+		}
+		return &remoteCache{
+			ETag:         response.Header.Get("ETag"),
+			LastModified: response.Header.Get("Last-Modified"),
+			content:      content,
+		}, nil
+	}
+	return nil, errors.Errorf("Server responded with %s", response.Status)
+}
+
+// remoteSignatureUrl is the URL of the detached signature, the one of the config with the .sig extension.
+func remoteSignatureUrl(remoteUrl string) string {
+	parsedUrl, err := url.Parse(remoteUrl)
+	if err != nil {
+		return remoteUrl + signatureSuffix
+	}
+	parsedUrl.Path += signatureSuffix
+	parsedUrl.RawPath = ""
+	return parsedUrl.String()
+}
+
+func verifyRemoteChecksum(remote *remote, content []byte) error {
+	if remote.Sha256 == "" {
+		return nil
+	}
+	checksum := sha256.Sum256(content)
+	if got := hex.EncodeToString(checksum[:]); got != remote.Sha256 {
+		return errors.Errorf("Remote config SHA-256 is %s, want %s", got, remote.Sha256)
+	}
+	return nil
+}
+
+// remoteCacheFile is the file caching the remote config under the user cache dir, named by the hash of its URL. No
+// user cache dir caches nothing.
+func remoteCacheFile(remoteUrl string) string {
+	cacheDir, err := userCacheDir()
+	if err != nil || cacheDir == "" {
+		return ""
+	}
+	urlHash := sha256.Sum256([]byte(remoteUrl))
+	return filepath.Join(cacheDir, configFileDir, remoteCacheDir, hex.EncodeToString(urlHash[:]))
+}
+
+// readRemoteCache reads the cached copy with its validators. No cached copy reads to nil.
+func readRemoteCache(cacheFile string) *remoteCache {
+	if cacheFile == "" {
+		return nil
+	}
+	content, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return nil
+	}
+	signature, _ := os.ReadFile(cacheFile + signatureSuffix)
+	cache := &remoteCache{content: content, signature: signature}
+	if validators, err := os.ReadFile(cacheFile + ".json"); err == nil {
+		_ = json.Unmarshal(validators, cache)
+	}
+	return cache
+}
+
+func writeRemoteCache(cacheFile string, cache *remoteCache) {
+	if cacheFile == "" {
+		return
+	}
+	validators, err := json.Marshal(cache)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(cacheFile), 0o700)
+	}
+	if err == nil {
+		err = os.WriteFile(cacheFile, cache.content, 0o600)
+	}
+	if err == nil && cache.signature != nil {
+		err = os.WriteFile(cacheFile+signatureSuffix, cache.signature, 0o600)
+	}
+	if err == nil {
+		err = os.WriteFile(cacheFile+".json", validators, 0o600)
+	}
+	if err != nil {
+		lctx.LogErrorf("Could not cache the remote config in %s: %v", cacheFile, err)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+)
+
+// SchemaUrl is where the JSON Schema of the config is published, e.g. for the "# yaml-language-server: $schema=" header.
+const SchemaUrl = "https://launchee.jdheim.com/schema/launchee.schema.json"
+
+const (
+	urlPattern      = "^[A-Za-z][A-Za-z0-9+.-]*:.+"
+	durationPattern = "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+)
+
+// jsonSchema is the subset of JSON Schema needed to describe the config.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Id                   string                 `json:"$id,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	Minimum              *int                   `json:"minimum,omitempty"`
+	Maximum              *int                   `json:"maximum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	OneOf                []*jsonSchema          `json:"oneOf,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// schemaConstraints add the limits checked by the validator to the generated properties, keyed by "<type>.<key>".
+var schemaConstraints = map[string]func(*jsonSchema){
+	"config.version":       withRange(1, CurrentVersion()),
+	"config.title":         withLength(minNameLength, maxNameLength),
+	"config.urlSchemes":    withItemsPattern("^[A-Za-z][A-Za-z0-9+.-]*$"),
+	"config.sort":          withEnum(sortConfig, sortMostUsed, sortRecent, sortAlphabetical),
+	"config.validation":    withEnum(validationStrict, validationLenient),
+	"remote.url":           withPattern("^https?://.+"),
+	"remote.sha256":        withPattern("^[0-9a-fA-F]{64}$"),
+	"remote.timeout":       withPattern(durationPattern),
+	"profile.name":         withLength(minNameLength, maxNameLength),
+	"profile.title":        withLength(minNameLength, maxNameLength),
+	"browser.name":         withLength(minNameLength, maxNameLength),
+	"autoHide.delay":       withPattern(durationPattern),
+	"autoHide.mode":        withEnum(frontend.AutoHideModeStrip, frontend.AutoHideModeHide),
+	"behavior.afterLaunch": withAfterLaunch,
+	"recent.limit":         withRange(1, maxRecentItems),
+	"recent.mimeTypes":     withItemsPattern("^[^/]+/[^/]+$"),
+	"shortcut.id":          withPattern(shortcutIdRegexp.String()),
+	"shortcut.name":        withLength(minNameLength, maxNameLength),
+	"shortcut.url":         withPattern(urlPattern),
+	"shortcut.afterLaunch": withAfterLaunch,
+	"shortcut.$patch":      withEnum(patchReplace, patchMerge, patchDelete, patchMove, patchRename),
+	"shortcut.newName":     withLength(minNameLength, maxNameLength),
+	"shortcut.before":      withLength(1, maxNameLength),
+	"shortcut.after":       withLength(1, maxNameLength),
+	"shortcut.position":    withMinimum(1),
+	"action.url":           withPattern(urlPattern),
+	"waitFor.delay":        withPattern(durationPattern),
+	"waitFor.port":         withPattern(":[^:]+$"),
+	"menuItem.label":       withLength(minNameLength, maxNameLength),
+	"menuItem.url":         withPattern(urlPattern),
+	"when.os":              withEnum(supportedOS...),
+}
+
+// schemaRequired are the keys required regardless of the patch mode, keyed by type.
+var schemaRequired = map[string][]string{
+	"browser":  {"name", "command"},
+	"profile":  {"name"},
+	"remote":   {"url"},
+	"shortcut": {"name"},
+	"menuItem": {"label"},
+}
+
+// Schema generates the JSON Schema of the config from its structs, so that editors complete and check launchee.yml.
+func Schema() ([]byte, error) {
+	defs := make(map[string]*jsonSchema)
+	schema := structSchema(reflect.TypeFor[config](), defs)
+	schema.Schema = "https://json-schema.org/draft/2020-12/schema"
+	schema.Id = SchemaUrl
+	schema.Title = "Launchee config"
+	schema.Defs = defs
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// structSchema describes the struct by its yaml keys. Nested structs are added to defs and referenced.
+func structSchema(structType reflect.Type, defs map[string]*jsonSchema) *jsonSchema {
+	additionalProperties := false
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		Required:             schemaRequired[structType.Name()],
+		AdditionalProperties: &additionalProperties,
+	}
+	for _, field := range reflect.VisibleFields(structType) {
+		key := yamlKey(field)
+		if key == "" {
+			continue
+		}
+		property := typeSchema(field.Type, defs)
+		if constrain, ok := schemaConstraints[structType.Name()+"."+key]; ok {
+			constrain(property)
+		}
+		schema.Properties[key] = property
+	}
+	return schema
+}
+
+func typeSchema(fieldType reflect.Type, defs map[string]*jsonSchema) *jsonSchema {
+	switch fieldType.Kind() {
+	case reflect.Pointer:
+		return typeSchema(fieldType.Elem(), defs)
+	case reflect.Slice:
+		return &jsonSchema{Type: "array", Items: typeSchema(fieldType.Elem(), defs)}
+	case reflect.Struct:
+		if fieldType == reflect.TypeFor[commandArgs]() {
+			return commandArgsSchema()
+		}
+		if _, ok := defs[fieldType.Name()]; !ok {
+			defs[fieldType.Name()] = nil // Marks the struct as in progress
+			defs[fieldType.Name()] = structSchema(fieldType, defs)
+		}
+		return &jsonSchema{Ref: "#/$defs/" + fieldType.Name()}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int:
+		return &jsonSchema{Type: "integer"}
+	default:
+		return &jsonSchema{Type: "string"}
+	}
+}
+
+// commandArgsSchema describes the arguments given as a string, a list or extending the inherited ones in merge mode.
+func commandArgsSchema() *jsonSchema {
+	additionalProperties := false
+	stringList := func() *jsonSchema {
+		return &jsonSchema{Type: "array", Items: &jsonSchema{Type: "string"}}
+	}
+	return &jsonSchema{OneOf: []*jsonSchema{
+		{Type: "string"},
+		stringList(),
+		{
+			Type: "object",
+			Properties: map[string]*jsonSchema{
+				commandArgsAppend:  stringList(),
+				commandArgsPrepend: stringList(),
+			},
+			AdditionalProperties: &additionalProperties,
+		},
+	}}
+}
+
+// yamlKey returns the key of the field the same way as yaml.v3, or "" when the field is not unmarshalled.
+func yamlKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	switch {
+	case key == "-":
+		return ""
+	case key == "":
+		return strings.ToLower(field.Name)
+	default:
+		return key
+	}
+}
+
+func withLength(minLength int, maxLength int) func(*jsonSchema) {
+	return func(schema *jsonSchema) {
+		schema.MinLength = &minLength
+		schema.MaxLength = &maxLength
+	}
+}
+
+func withRange(minimum int, maximum int) func(*jsonSchema) {
+	return func(schema *jsonSchema) {
+		schema.Minimum = &minimum
+		schema.Maximum = &maximum
+	}
+}
+
+func withMinimum(minimum int) func(*jsonSchema) {
+	return func(schema *jsonSchema) {
+		schema.Minimum = &minimum
+	}
+}
+
+func withEnum(values ...string) func(*jsonSchema) {
+	return func(schema *jsonSchema) {
+		schema.Enum = values
+	}
+}
+
+func withPattern(pattern string) func(*jsonSchema) {
+	return func(schema *jsonSchema) {
+		schema.Pattern = pattern
+	}
+}
+
+func withItemsPattern(pattern string) func(*jsonSchema) {
+	return func(schema *jsonSchema) {
+		schema.Items.Pattern = pattern
+	}
+}
+
+var withAfterLaunch = withEnum(frontend.AfterLaunchKeep, frontend.AfterLaunchMinimise, frontend.AfterLaunchHide,
+	frontend.AfterLaunchQuit)
@@ -0,0 +1,89 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const committedSchemaPath = "../../../../docs/static/schema/launchee.schema.json"
+
+func TestSchemaCommitted(t *testing.T) {
+	schema, err := Schema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	committedSchema, err := os.ReadFile(committedSchemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(schema)+"\n" != string(committedSchema) {
+		t.Errorf("Schema() differs from %s, regenerate it with: go run . --print-schema > %s", committedSchemaPath,
+			strings.TrimPrefix(committedSchemaPath, "../../../"))
+	}
+}
+
+func TestSchemaConstraints(t *testing.T) {
+	defs := make(map[string]*jsonSchema)
+	defs["config"] = structSchema(reflect.TypeFor[config](), defs)
+	for name := range schemaConstraints {
+		t.Run(name, func(t *testing.T) {
+			typeName, key, _ := strings.Cut(name, ".")
+			if defs[typeName] == nil || defs[typeName].Properties[key] == nil {
+				t.Fatalf("schemaConstraints[%q] constrains no property", name)
+			}
+			property := defs[typeName].Properties[key]
+			if property.Items != nil {
+				property = property.Items
+			}
+			if _, err := regexp.Compile(property.Pattern); err != nil {
+				t.Errorf("schemaConstraints[%q] has invalid pattern: %v", name, err)
+			}
+		})
+	}
+	for typeName, keys := range schemaRequired {
+		for _, key := range keys {
+			if defs[typeName] == nil || defs[typeName].Properties[key] == nil {
+				t.Errorf("schemaRequired[%q] requires unknown property %q", typeName, key)
+			}
+		}
+	}
+}
+
+func TestYamlKey(t *testing.T) {
+	testCases := map[string]struct {
+		in   reflect.StructField
+		want string
+	}{
+		"untagged":    {reflect.StructField{Name: "AfterLaunch"}, "afterlaunch"},
+		"tagged":      {reflect.StructField{Name: "AfterLaunch", Tag: `yaml:"afterLaunch"`}, "afterLaunch"},
+		"tag options": {reflect.StructField{Name: "Patch", Tag: `yaml:"$patch,omitempty"`}, "$patch"},
+		"skipped":     {reflect.StructField{Name: "Skipped", Tag: `yaml:"-"`}, ""},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := yamlKey(testCase.in); got != testCase.want {
+				t.Errorf("yamlKey() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	publicKeyFile   = "launchee.pub"
+	signatureSuffix = ".sig"
+)
+
+// configPublicKey is the ed25519 public key verifying the system config, set at build time with
+// -ldflags "-X github.com/jdheim/launchee/internal/config/yaml.configPublicKey=<key>". It takes precedence over the
+// launchee.pub file next to the system config.
+var configPublicKey string
+
+// isTrustedOwner tells whether the owner of the system config, its public key and their dir may be trusted, i.e. is
+// root.
+var isTrustedOwner = isRootOwned
+
+// checkTrustedFile checks that no other user than root can change the system config or its public key.
+var checkTrustedFile = checkOnlyRootWritable
+
+// signatureVerifier verifies the system config and the remote one it sets against tampering. Without a public key, only
+// the permissions of the system config are verified.
+type signatureVerifier struct {
+	publicKey ed25519.PublicKey
+}
+
+// newSignatureVerifier reads the public key set at build time or in the launchee.pub file next to the system config.
+func newSignatureVerifier(configFile string) (*signatureVerifier, error) {
+	if configPublicKey != "" {
+		publicKey, err := parsePublicKey([]byte(configPublicKey))
+		if err != nil {
+			return nil, errors.WithMessage(err, "Public key set at build time is invalid")
+		}
+		return &signatureVerifier{publicKey}, nil
+	}
+	if configFile == "" {
+		return &signatureVerifier{}, nil
+	}
+	keyFile := filepath.Join(filepath.Dir(configFile), publicKeyFile)
+	if _, err := os.Stat(keyFile); err != nil {
+		return &signatureVerifier{}, nil
+	}
+	if err := checkTrustedFile(keyFile); err != nil {
+		return nil, errors.WithMessagef(err, "Could not trust public key %s", keyFile)
+	}
+	content, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "Could not read public key %s", keyFile)
+	}
+	publicKey, err := parsePublicKey(content)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "Public key %s is invalid", keyFile)
+	}
+	return &signatureVerifier{publicKey}, nil
+}
+
+// verifyFile verifies that only root can write the config file and, with a public key, its detached signature in the
+// file of the same name with the .sig extension.
+func (sv *signatureVerifier) verifyFile(configFile string, content []byte) error {
+	if err := checkTrustedFile(configFile); err != nil {
+		return err
+	}
+	if sv.publicKey == nil {
+		return nil
+	}
+	signature, err := os.ReadFile(configFile + signatureSuffix)
+	if err != nil {
+		return errors.Errorf("Signature %s%s of %s is missing", configFile, signatureSuffix, configFile)
+	}
+	return sv.verify(configFile, content, signature)
+}
+
+// verify verifies the detached signature of the content, either raw or base64 encoded.
+func (sv *signatureVerifier) verify(source string, content []byte, signature []byte) error {
+	if sv == nil || sv.publicKey == nil {
+		return nil
+	}
+	if len(signature) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+		if err != nil || len(decoded) != ed25519.SignatureSize {
+			return errors.Errorf("Signature of %s is not an ed25519 signature", source)
+		}
+		signature = decoded
+	}
+	if !ed25519.Verify(sv.publicKey, content, signature) {
+		return errors.Errorf("Signature of %s does not match, the config may have been tampered with", source)
+	}
+	return nil
+}
+
+// requiresSignature tells whether the configs must be signed.
+func (sv *signatureVerifier) requiresSignature() bool {
+	return sv != nil && sv.publicKey != nil
+}
+
+// parsePublicKey parses the ed25519 public key, either PEM encoded as by "openssl pkey -pubout" or the raw key base64
+// encoded.
+func parsePublicKey(content []byte) (ed25519.PublicKey, error) {
+	if block, _ := pem.Decode(content); block != nil {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if publicKey, ok := key.(ed25519.PublicKey); ok {
+			return publicKey, nil
+		}
+		return nil, errors.New("Public key is not an ed25519 key")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil || len(decoded) != ed25519.PublicKeySize {
+		return nil, errors.New("Public key must be PEM encoded or the base64 encoded 32 bytes of an ed25519 key")
+	}
+	return decoded, nil
+}
+
+// checkOnlyRootWritable checks that the file and the dir containing it are owned by root and writable only by it, so
+// no other user can change or replace the file.
+func checkOnlyRootWritable(file string) error {
+	for _, path := range []string{file, filepath.Dir(file)} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err = checkNotWritableByOthers(path, info, 0o022); err != nil {
+			return err
+		}
+		if !isTrustedOwner(info) {
+			return errors.Errorf("%s must be owned by root", path)
+		}
+	}
+	return nil
+}
+
+// checkNotWritableByOthers rejects the file writable with any of the permission bits. Windows permissions are not
+// checked, as they are not reflected in the file mode.
+func checkNotWritableByOthers(file string, info os.FileInfo, writableBits os.FileMode) error {
+	if getGOOS() == "windows" || info.Mode().Perm()&writableBits == 0 {
+		return nil
+	}
+	return errors.Errorf("%s is writable by other users (mode %s), remove it with: chmod go-w %s", file,
+		info.Mode().Perm(), file)
+}
@@ -0,0 +1,323 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+// customConfigPath points the system and user configs to the given files.
+type customConfigPath struct {
+	systemConfigPath string
+	userConfigPath   string
+}
+
+func (ccp customConfigPath) GetSystemConfigPath() string {
+	return ccp.systemConfigPath
+}
+
+func (ccp customConfigPath) GetUserConfigPath() string {
+	return ccp.userConfigPath
+}
+
+func generateKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return publicKey, privateKey
+}
+
+func pemOf(t *testing.T, publicKey any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// useTrustedOwner trusts the owner of the public key files, which are not owned by root in tests.
+func useTrustedOwner(t *testing.T, trusted bool) {
+	t.Helper()
+	t.Cleanup(func() { isTrustedOwner = isRootOwned })
+	isTrustedOwner = func(os.FileInfo) bool { return trusted }
+}
+
+// useTrustedFiles trusts the system configs of the stubs, which are owned and may be writable by the developer.
+func useTrustedFiles(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() { checkTrustedFile = checkOnlyRootWritable })
+	checkTrustedFile = func(string) error { return nil }
+}
+
+func writeFile(t *testing.T, file string, content []byte, mode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(file, content, mode); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(file, mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	publicKey, _ := generateKey(t)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := map[string]struct {
+		in      []byte
+		wantErr bool
+	}{
+		"pem":         {pemOf(t, publicKey), false},
+		"base64":      {[]byte(base64.StdEncoding.EncodeToString(publicKey) + "\n"), false},
+		"ecdsa pem":   {pemOf(t, &ecdsaKey.PublicKey), true},
+		"short":       {[]byte(base64.StdEncoding.EncodeToString(publicKey[1:])), true},
+		"not base64":  {[]byte("not a key"), true},
+		"invalid pem": {[]byte("-----BEGIN PUBLIC KEY-----\nAAAA\n-----END PUBLIC KEY-----\n"), true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parsePublicKey(testCase.in)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Fatalf("parsePublicKey() = %v, want error %t", err, testCase.wantErr)
+			}
+			if !testCase.wantErr && !publicKey.Equal(got) {
+				t.Errorf("parsePublicKey() = %x, want %x", got, publicKey)
+			}
+		})
+	}
+}
+
+func TestSignatureVerifierVerify(t *testing.T) {
+	publicKey, privateKey := generateKey(t)
+	content := []byte("title: \"Signed\"\n")
+	signature := ed25519.Sign(privateKey, content)
+	testCases := map[string]struct {
+		verifier  *signatureVerifier
+		content   []byte
+		signature []byte
+		wantErr   bool
+	}{
+		"nil verifier":     {nil, content, nil, false},
+		"no public key":    {&signatureVerifier{}, content, nil, false},
+		"raw":              {&signatureVerifier{publicKey}, content, signature, false},
+		"base64":           {&signatureVerifier{publicKey}, content, []byte(base64.StdEncoding.EncodeToString(signature) + "\n"), false},
+		"tampered":         {&signatureVerifier{publicKey}, []byte("title: \"Tampered\"\n"), signature, true},
+		"no signature":     {&signatureVerifier{publicKey}, content, nil, true},
+		"invalid encoding": {&signatureVerifier{publicKey}, content, []byte("not a signature"), true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.verifier.verify("launchee.yml", testCase.content, testCase.signature)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("verify() = %v, want error %t", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSignatureVerifier(t *testing.T) {
+	publicKey, _ := generateKey(t)
+	testCases := map[string]struct {
+		buildKey      string
+		keyFile       []byte
+		keyFileMode   os.FileMode
+		trustedOwner  bool
+		wantPublicKey bool
+		wantErr       bool
+	}{
+		"no key":              {"", nil, 0, true, false, false},
+		"key file":            {"", pemOf(t, publicKey), 0o644, true, true, false},
+		"build key":           {base64.StdEncoding.EncodeToString(publicKey), nil, 0, true, true, false},
+		"build key first":     {base64.StdEncoding.EncodeToString(publicKey), []byte("not a key"), 0o644, true, true, false},
+		"invalid build key":   {"not a key", nil, 0, true, false, true},
+		"invalid key file":    {"", []byte("not a key"), 0o644, true, false, true},
+		"group writable key":  {"", pemOf(t, publicKey), 0o664, true, false, true},
+		"untrusted key owner": {"", pemOf(t, publicKey), 0o644, false, false, true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			useTrustedOwner(t, testCase.trustedOwner)
+			t.Cleanup(func() { configPublicKey = "" })
+			configPublicKey = testCase.buildKey
+			configDir := t.TempDir()
+			if testCase.keyFile != nil {
+				writeFile(t, filepath.Join(configDir, publicKeyFile), testCase.keyFile, testCase.keyFileMode)
+			}
+			got, err := newSignatureVerifier(filepath.Join(configDir, configFileYML))
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Fatalf("newSignatureVerifier() = %v, want error %t", err, testCase.wantErr)
+			}
+			if !testCase.wantErr && got.requiresSignature() != testCase.wantPublicKey {
+				t.Errorf("newSignatureVerifier().requiresSignature() = %t, want %t", got.requiresSignature(),
+					testCase.wantPublicKey)
+			}
+		})
+	}
+}
+
+func TestUnmarshalConfigsSigned(t *testing.T) {
+	defer func() { ConfigPathImpl = systemAwareConfigPath{} }()
+	lctx.LoggerImpl = stub.LoggerStub{}
+	useTrustedOwner(t, true)
+	publicKey, privateKey := generateKey(t)
+	content := []byte("title: \"Signed\"\n")
+	testCases := map[string]struct {
+		publicKey  []byte
+		signature  []byte
+		configMode os.FileMode
+		wantErr    bool
+	}{
+		"unsigned":         {nil, nil, 0o644, false},
+		"signed":           {pemOf(t, publicKey), ed25519.Sign(privateKey, content), 0o644, false},
+		"signature only":   {nil, ed25519.Sign(privateKey, content), 0o644, false},
+		"missing":          {pemOf(t, publicKey), nil, 0o644, true},
+		"tampered":         {pemOf(t, publicKey), ed25519.Sign(privateKey, []byte("title: \"Original\"\n")), 0o644, true},
+		"world writable":   {nil, nil, 0o666, true},
+		"group writable":   {nil, nil, 0o664, true},
+		"signed, writable": {pemOf(t, publicKey), ed25519.Sign(privateKey, content), 0o646, true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), configFileYML)
+			writeFile(t, configFile, content, testCase.configMode)
+			if testCase.publicKey != nil {
+				writeFile(t, filepath.Join(filepath.Dir(configFile), publicKeyFile), testCase.publicKey, 0o644)
+			}
+			if testCase.signature != nil {
+				writeFile(t, configFile+signatureSuffix, testCase.signature, 0o644)
+			}
+			ConfigPathImpl = customConfigPath{systemConfigPath: configFile}
+			got, err := UnmarshalConfigs("")
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Fatalf("UnmarshalConfigs() = %v, want error %t", err, testCase.wantErr)
+			}
+			if !testCase.wantErr && got.UI.Nav.Title != "Signed" {
+				t.Errorf("UnmarshalConfigs() = %q, want Signed", got.UI.Nav.Title)
+			}
+		})
+	}
+}
+
+func TestCheckOnlyRootWritable(t *testing.T) {
+	testCases := map[string]struct {
+		fileMode     os.FileMode
+		dirMode      os.FileMode
+		trustedOwner bool
+		wantErr      bool
+	}{
+		"root only":            {0o644, 0o755, true, false},
+		"group writable file":  {0o664, 0o755, true, true},
+		"world writable file":  {0o646, 0o755, true, true},
+		"group writable dir":   {0o644, 0o775, true, true},
+		"world writable dir":   {0o644, 0o757, true, true},
+		"untrusted owner":      {0o644, 0o755, false, true},
+		"read-only everywhere": {0o444, 0o555, true, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			useTrustedOwner(t, testCase.trustedOwner)
+			dir := filepath.Join(t.TempDir(), "launchee")
+			if err := os.Mkdir(dir, 0o700); err != nil {
+				t.Fatal(err)
+			}
+			file := filepath.Join(dir, configFileYML)
+			writeFile(t, file, nil, testCase.fileMode)
+			if err := os.Chmod(dir, testCase.dirMode); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = os.Chmod(dir, 0o700) })
+			if err := checkOnlyRootWritable(file); (err != nil) != testCase.wantErr {
+				t.Errorf("checkOnlyRootWritable() = %v, want error %t", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnmarshalRemoteConfigSigned(t *testing.T) {
+	lctx.LoggerImpl = stub.LoggerStub{}
+	publicKey, privateKey := generateKey(t)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/signed.yml", "/unsigned.yml":
+			_, _ = writer.Write([]byte(remoteConfigContent))
+		case "/signed.yml.sig":
+			_, _ = writer.Write(ed25519.Sign(privateKey, []byte(remoteConfigContent)))
+		default:
+			http.NotFound(writer, request)
+		}
+	}))
+	defer server.Close()
+	testCases := map[string]struct {
+		url      string
+		verifier *signatureVerifier
+		wantErr  bool
+	}{
+		"signed":             {server.URL + "/signed.yml", &signatureVerifier{publicKey}, false},
+		"unsigned":           {server.URL + "/unsigned.yml", &signatureVerifier{publicKey}, true},
+		"unsigned allowed":   {server.URL + "/unsigned.yml", &signatureVerifier{}, false},
+		"signed with query":  {server.URL + "/signed.yml?token=secret", &signatureVerifier{publicKey}, false},
+		"no verifier needed": {server.URL + "/unsigned.yml", nil, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			useTestCacheDir(t)
+			got := unmarshalRemoteConfig(&config{Remote: &remote{Url: testCase.url}}, testCase.verifier)
+			if gotErr := got.err != nil; gotErr != testCase.wantErr {
+				t.Errorf("unmarshalRemoteConfig() = %v, want error %t", got.err, testCase.wantErr)
+			}
+		})
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		useTestCacheDir(t)
+		offline := httptest.NewServer(server.Config.Handler)
+		remote := &remote{Url: offline.URL + "/signed.yml"}
+		verifier := &signatureVerifier{publicKey}
+		if got := unmarshalRemoteConfig(&config{Remote: remote}, verifier); got.err != nil {
+			t.Fatalf("unmarshalRemoteConfig() = %v", got.err)
+		}
+		offline.Close()
+		if got := unmarshalRemoteConfig(&config{Remote: remote}, verifier); got.err != nil {
+			t.Errorf("unmarshalRemoteConfig() offline = %v, want the cached signed copy", got.err)
+		}
+	})
+}
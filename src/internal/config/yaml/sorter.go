@@ -0,0 +1,84 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/stats"
+)
+
+const (
+	sortConfig       = "config"
+	sortMostUsed     = "mostUsed"
+	sortRecent       = "recent"
+	sortAlphabetical = "alphabetical"
+)
+
+// UsageStore provides how often and when the shortcuts were launched, to sort them by.
+type UsageStore interface {
+	Load() (map[string]*stats.Usage, error)
+}
+
+var UsageStoreImpl UsageStore = stats.NewStore(stats.Path)
+
+// sortedShortcuts returns the shortcuts in the configured order. The ones that compare equal keep the config order.
+func (yc *config) sortedShortcuts() []*shortcut {
+	sorted := slices.Clone(yc.Shortcuts)
+	switch yc.Sort {
+	case sortAlphabetical:
+		slices.SortStableFunc(sorted, func(a, b *shortcut) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	case sortMostUsed, sortRecent:
+		usage, err := UsageStoreImpl.Load()
+		if err != nil {
+			lctx.LogErrorf("Shortcuts are sorted as if they had never been launched: %v", err)
+		}
+		slices.SortStableFunc(sorted, func(a, b *shortcut) int {
+			return compareUsage(yc.Sort, usageOf(usage, a.key(), a.Name), usageOf(usage, b.key(), b.Name))
+		})
+	}
+	return sorted
+}
+
+// usageOf returns the usage of the shortcut recorded by its stable id or, before it had an id, by its name.
+func usageOf(usage map[string]*stats.Usage, stableId string, name string) *stats.Usage {
+	if shortcutUsage, found := usage[stableId]; found {
+		return shortcutUsage
+	}
+	return usage[name]
+}
+
+// compareUsage orders the more used or, for ties and the recent sort, the more recently launched shortcut first.
+func compareUsage(sort string, a *stats.Usage, b *stats.Usage) int {
+	if a == nil {
+		a = &stats.Usage{}
+	}
+	if b == nil {
+		b = &stats.Usage{}
+	}
+	if sort == sortMostUsed {
+		if launches := cmp.Compare(b.Launches, a.Launches); launches != 0 {
+			return launches
+		}
+	}
+	return b.LastLaunch.Compare(a.LastLaunch)
+}
@@ -0,0 +1,100 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+func TestSortedShortcuts(t *testing.T) {
+	shortcuts := []*shortcut{{Name: "terminal"}, {Name: "Firefox"}, {Name: "Files"}, {Name: "Terminal"}}
+	testCases := map[string]struct {
+		sort       string
+		usageStore UsageStore
+		want       []string
+	}{
+		"default":         {"", stub.UsageStoreStub{}, []string{"terminal", "Firefox", "Files", "Terminal"}},
+		"config":          {sortConfig, stub.UsageStoreStub{}, []string{"terminal", "Firefox", "Files", "Terminal"}},
+		"alphabetical":    {sortAlphabetical, stub.UsageStoreStub{}, []string{"Files", "Firefox", "terminal", "Terminal"}},
+		"most used":       {sortMostUsed, stub.UsageStoreStub{}, []string{"Firefox", "Terminal", "terminal", "Files"}},
+		"recent":          {sortRecent, stub.UsageStoreStub{}, []string{"Terminal", "Firefox", "terminal", "Files"}},
+		"corrupted store": {sortMostUsed, stub.UsageStoreCorruptedStub{}, []string{"terminal", "Firefox", "Files", "Terminal"}},
+	}
+
+	originalUsageStoreImpl := UsageStoreImpl
+	defer func() { UsageStoreImpl = originalUsageStoreImpl }()
+	lctx.LoggerImpl = stub.LoggerStub{}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			UsageStoreImpl = testCase.usageStore
+			yc := &config{Sort: testCase.sort, Shortcuts: shortcuts}
+			var got []string
+			for _, shortcut := range yc.sortedShortcuts() {
+				got = append(got, shortcut.Name)
+			}
+			if diff := cmp.Diff(testCase.want, got); diff != "" {
+				t.Errorf("sortedShortcuts() = diff -want +got\n%s", diff)
+			}
+			if shortcuts[0].Name != "terminal" {
+				t.Error("sortedShortcuts() reordered the config")
+			}
+		})
+	}
+}
+
+func TestUsageOf(t *testing.T) {
+	usage, _ := stub.UsageStoreStub{}.Load()
+	testCases := map[string]struct {
+		stableId     string
+		name         string
+		wantLaunches int
+	}{
+		"by name":              {"Firefox", "Firefox", 5},
+		"by id before renamed": {"firefox", "Firefox", 5},
+		"by id":                {"Terminal", "Firefox", 2},
+		"never launched":       {"files", "Files", 0},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var gotLaunches int
+			if got := usageOf(usage, testCase.stableId, testCase.name); got != nil {
+				gotLaunches = got.Launches
+			}
+			if gotLaunches != testCase.wantLaunches {
+				t.Errorf("usageOf(%q, %q) = %d launches, want %d", testCase.stableId, testCase.name, gotLaunches,
+					testCase.wantLaunches)
+			}
+		})
+	}
+}
+
+func TestToFrontendShortcutsSorted(t *testing.T) {
+	originalUsageStoreImpl := UsageStoreImpl
+	defer func() { UsageStoreImpl = originalUsageStoreImpl }()
+	UsageStoreImpl = stub.UsageStoreStub{}
+
+	yc := &config{Sort: sortMostUsed, Shortcuts: []*shortcut{{Name: "Terminal"}, {Name: "Firefox"}}}
+	got := yc.toFrontendShortcuts()
+	if got[0].Id != 0 || got[0].Name != "Firefox" || got[1].Id != 1 || got[1].Name != "Terminal" {
+		t.Errorf("toFrontendShortcuts() = %d %s, %d %s, want 0 Firefox, 1 Terminal", got[0].Id, got[0].Name, got[1].Id, got[1].Name)
+	}
+}
@@ -20,13 +20,13 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sync"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/pkg/errors"
 
 	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
 )
 
 const (
@@ -40,62 +40,186 @@ type unmarshalResult struct {
 	err    error
 }
 
-func UnmarshalCustomConfig(customConfigPath string) (*frontend.Config, error) {
-	customConfigPathResult := unmarshalConfigFile(customConfigPath)
+// UnmarshalCustomConfig unmarshals the custom config instead of the system and user ones. When the system config, or
+// the remote one it sets, has a policy, the custom config is merged over them as the user config instead, so it cannot
+// get around the policy.
+func UnmarshalCustomConfig(customConfigPath string, profile string) (*frontend.Config, error) {
+	systemConfigResult, remoteConfigResult, customConfigResult := unmarshalConfigsAsync(customConfigPath)
+	if systemConfigResult.err != nil {
+		return frontend.NewConfig(0), systemConfigResult.err
+	}
+	if remoteConfigResult.err != nil {
+		return frontend.NewConfig(0), remoteConfigResult.err
+	}
+	if systemConfigResult.hasPolicy() || remoteConfigResult.hasPolicy() {
+		lctx.LogInfof("Merging %s over the system config, as the system config sets a Policy", customConfigPath)
+		return mergeUserConfig(systemConfigResult, remoteConfigResult, customConfigResult, profile)
+	}
+	customConfigPathResult := customConfigResult.validated("", nil)
 	if customConfigPathResult.err != nil {
 		return frontend.NewConfig(0), customConfigPathResult.err
 	}
-	if customConfigPathResult.config != nil {
-		return customConfigPathResult.config.sanitize().toFrontendConfig(), nil
+	customConfig := customConfigPathResult.config.sanitize()
+	if customConfig != nil {
+		remoteConfigResult := unmarshalRemoteConfig(customConfig, nil).validated(customConfig.Validation,
+			customConfig.UrlSchemes)
+		if remoteConfigResult.err != nil {
+			return frontend.NewConfig(0), remoteConfigResult.err
+		}
+		customConfig = customConfig.merge(remoteConfigResult.config)
 	}
-	return frontend.NewConfig(0), nil
+	return toFrontendConfigWithProfile(customConfig, profile)
 }
 
-func UnmarshalConfigs() (*frontend.Config, error) {
-	systemConfigResult, userConfigResult := unmarshalConfigsAsync()
+func UnmarshalConfigs(profile string) (*frontend.Config, error) {
+	systemConfigResult, remoteConfigResult, userConfigResult := unmarshalConfigsAsync(ConfigPathImpl.GetUserConfigPath())
 	if systemConfigResult.err != nil {
 		return frontend.NewConfig(0), systemConfigResult.err
 	}
+	if remoteConfigResult.err != nil {
+		return frontend.NewConfig(0), remoteConfigResult.err
+	}
+	return mergeUserConfig(systemConfigResult, remoteConfigResult, userConfigResult, profile)
+}
+
+// mergeUserConfig validates the user config, whose shortcuts may use the URL schemes of the system and remote
+// configs, and merges it over them through the policy of the system config. No system config uses the user config
+// alone.
+func mergeUserConfig(systemConfigResult *unmarshalResult, remoteConfigResult *unmarshalResult,
+	userConfigResult *unmarshalResult, profile string) (*frontend.Config, error) {
+	if userConfigResult.config != nil && userConfigResult.config.Remote != nil {
+		lctx.LogInfo("Ignoring Remote of the user config, as only the system config can set it")
+	}
+	userConfigResult = userConfigResult.validated("", slices.Concat(systemConfigResult.urlSchemes(),
+		remoteConfigResult.urlSchemes()))
 	if userConfigResult.err != nil {
 		return frontend.NewConfig(0), userConfigResult.err
 	}
 	if systemConfigResult.config != nil {
-		return systemConfigResult.config.sanitize().merge(userConfigResult.config).toFrontendConfig(), nil
-	} else if userConfigResult.config != nil {
-		return userConfigResult.config.sanitize().toFrontendConfig(), nil
+		systemConfig := systemConfigResult.config.sanitize().merge(remoteConfigResult.config)
+		return toFrontendConfigWithProfile(systemConfig.mergeUser(userConfigResult.config), profile)
 	}
-	return frontend.NewConfig(0), nil
+	return toFrontendConfigWithProfile(userConfigResult.config.sanitize(), profile)
 }
 
-func unmarshalConfigsAsync() (*unmarshalResult, *unmarshalResult) {
+// toFrontendConfigWithProfile converts the config patched with the profile, whose shortcuts must have unique ids. No
+// config converts to the default one.
+func toFrontendConfigWithProfile(config *config, profile string) (*frontend.Config, error) {
+	profiledConfig, err := config.applyProfile(profile)
+	if err != nil {
+		return frontend.NewConfig(0), err
+	}
+	if err = validateMergedShortcuts(profiledConfig); err != nil {
+		return frontend.NewConfig(0), err
+	}
+	frontendConfig := profiledConfig.toFrontendConfig()
+	frontendConfig.Profile = profile
+	return frontendConfig, nil
+}
+
+// unmarshalConfigsAsync unmarshals the system config with the remote one it sets, both validated, and the user config
+// file, which is validated once it is known what it is merged over.
+func unmarshalConfigsAsync(userConfigFile string) (*unmarshalResult, *unmarshalResult, *unmarshalResult) {
 	var systemConfigResult *unmarshalResult
+	var remoteConfigResult *unmarshalResult
 	var userConfigResult *unmarshalResult
 	var wg sync.WaitGroup
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
-		systemConfigResult = unmarshalConfigFile(ConfigPathImpl.GetSystemConfigPath())
+		systemConfigResult, remoteConfigResult = unmarshalSystemConfig(ConfigPathImpl.GetSystemConfigPath())
 	}()
 	go func() {
 		defer wg.Done()
-		userConfigResult = unmarshalConfigFile(ConfigPathImpl.GetUserConfigPath())
+		userConfigResult = unmarshalConfigFile(userConfigFile, nil)
 	}()
 	wg.Wait()
-	return systemConfigResult, userConfigResult
+	// The user may make the validation of the system config lenient, e.g. to start despite its missing icon
+	var userValidation string
+	if userConfigResult.config != nil {
+		userValidation = userConfigResult.config.Validation
+	}
+	// The shortcuts of the remote config may use the URL schemes of the system config
+	return systemConfigResult.validated(userValidation, nil),
+		remoteConfigResult.validated(userValidation, systemConfigResult.urlSchemes()), userConfigResult
 }
 
-func unmarshalConfigFile(configFile string) *unmarshalResult {
+// unmarshalSystemConfig unmarshals the system config and the remote one it sets, both verified against tampering.
+func unmarshalSystemConfig(configFile string) (*unmarshalResult, *unmarshalResult) {
+	verifier, err := newSignatureVerifier(configFile)
+	if err != nil {
+		return &unmarshalResult{nil, err}, &unmarshalResult{nil, nil}
+	}
+	systemConfigResult := unmarshalConfigFile(configFile, verifier)
+	return systemConfigResult, unmarshalRemoteConfig(systemConfigResult.config, verifier)
+}
+
+// unmarshalConfigFile unmarshals the config file. A verifier rejects the file that may have been tampered with, i.e.
+// writable by other users than root or not matching its signature.
+func unmarshalConfigFile(configFile string, verifier *signatureVerifier) *unmarshalResult {
 	if bytes, err := os.ReadFile(configFile); err == nil {
-		var config *config
-		if err = yaml.Unmarshal(bytes, &config); err != nil {
-			return &unmarshalResult{nil, errors.WithMessagef(err, "Could not parse %s", configFile)}
+		if verifier != nil {
+			if err = verifier.verifyFile(configFile, bytes); err != nil {
+				return &unmarshalResult{nil, errors.WithMessagef(err, "Could not verify %s", configFile)}
+			}
 		}
-		config.trim()
-		return &unmarshalResult{config, validate(config)}
+		return unmarshalConfigContent(configFile, bytes)
 	}
 	return &unmarshalResult{nil, nil}
 }
 
+// unmarshalConfigContent unmarshals the content of the config file, whose extension tells its format.
+func unmarshalConfigContent(configFile string, content []byte) *unmarshalResult {
+	document, err := parseDocument(configFile, content)
+	if err != nil {
+		return &unmarshalResult{nil, errors.WithMessagef(err, "Could not parse %s", configFile)}
+	}
+	version, err := migrateDocument(document)
+	if err != nil {
+		return &unmarshalResult{nil, errors.WithMessagef(err, "Could not migrate %s", configFile)}
+	}
+	if version != CurrentVersion() {
+		lctx.LogInfof("Read %s of Version %d as %d, run \"launchee migrate\" to update it", configFile, version,
+			CurrentVersion())
+	}
+	var config *config
+	if len(document.Content) != 0 {
+		if err = document.Decode(&config); err != nil {
+			return &unmarshalResult{nil, errors.WithMessagef(err, "Could not parse %s", configFile)}
+		}
+	}
+	config.trim()
+	if err = config.dropUnmetShortcuts(); err != nil {
+		return &unmarshalResult{config, errors.WithMessagef(err, "Could not apply When of %s", configFile)}
+	}
+	return &unmarshalResult{config, nil}
+}
+
+// validated validates the unmarshalled config with the given validation, unless empty, instead of its own. Its
+// shortcuts may use the inherited URL schemes, of the configs it is merged over.
+func (ur *unmarshalResult) validated(validation string, inheritedUrlSchemes []string) *unmarshalResult {
+	if ur.err != nil || ur.config == nil {
+		return ur
+	}
+	if validation != "" {
+		ur.config.Validation = validation
+	}
+	ur.config.InheritedUrlSchemes = inheritedUrlSchemes
+	ur.err = validate(ur.config)
+	return ur
+}
+
+func (ur *unmarshalResult) hasPolicy() bool {
+	return ur.config != nil && ur.config.Policy != nil
+}
+
+func (ur *unmarshalResult) urlSchemes() []string {
+	if ur.config == nil {
+		return nil
+	}
+	return ur.config.UrlSchemes
+}
+
 type ConfigPath interface {
 	GetSystemConfigPath() string
 	GetUserConfigPath() string
@@ -138,13 +262,17 @@ func findConfigFile(dir string) string {
 	if dir == "" {
 		return ""
 	}
-	configFileYmlPath := filepath.Join(dir, configFileDir, configFileYML)
-	if _, err := os.Stat(configFileYmlPath); err == nil {
-		return configFileYmlPath
-	}
-	configFileYamlPath := filepath.Join(dir, configFileDir, configFileYAML)
-	if _, err := os.Stat(configFileYamlPath); err == nil {
-		return configFileYamlPath
+	var found string
+	for _, configFileName := range configFileNames {
+		configFilePath := filepath.Join(dir, configFileDir, configFileName)
+		if _, err := os.Stat(configFilePath); err != nil {
+			continue
+		}
+		if found != "" {
+			lctx.LogInfof("Ignoring %s, as %s takes precedence", configFilePath, found)
+			continue
+		}
+		found = configFilePath
 	}
-	return ""
+	return found
 }
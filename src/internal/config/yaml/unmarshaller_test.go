@@ -19,6 +19,7 @@ package yaml
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/jdheim/launchee/internal/lctx"
@@ -41,7 +42,7 @@ func TestUnmarshalCustomConfig(t *testing.T) {
 	lctx.LoggerImpl = stub.LoggerStub{}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
-			_, err := UnmarshalCustomConfig(testCase.configPathStub.GetSystemConfigPath())
+			_, err := UnmarshalCustomConfig(testCase.configPathStub.GetSystemConfigPath(), "")
 			if gotErr := err == nil; gotErr == testCase.wantErr {
 				t.Errorf("UnmarshalCustomConfig() = %t, want %t", gotErr, testCase.wantErr)
 			}
@@ -52,6 +53,7 @@ func TestUnmarshalCustomConfig(t *testing.T) {
 func TestUnmarshalConfigs(t *testing.T) {
 	defer chdirBack(t)
 	chdirToRoot(t)
+	useTrustedFiles(t)
 	testCases := map[string]struct {
 		configPathStub ConfigPath
 		wantErr        bool
@@ -66,6 +68,8 @@ func TestUnmarshalConfigs(t *testing.T) {
 		"empty":             {stub.ConfigPathEmptyStub{}, false},
 		"system empty":      {stub.SystemConfigPathEmptyStub{}, false},
 		"user empty":        {stub.UserConfigPathEmptyStub{}, false},
+		"system broken":     {stub.SystemConfigPathBrokenStub{}, true},
+		"lenient":           {stub.ConfigPathLenientStub{}, false},
 	}
 
 	lctx.LoggerImpl = stub.LoggerStub{}
@@ -73,7 +77,7 @@ func TestUnmarshalConfigs(t *testing.T) {
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
 			ConfigPathImpl = testCase.configPathStub
-			_, err := UnmarshalConfigs()
+			_, err := UnmarshalConfigs("")
 			if gotErr := err == nil; gotErr == testCase.wantErr {
 				t.Errorf("UnmarshalConfigs() = %t, want %t", gotErr, testCase.wantErr)
 			}
@@ -81,6 +85,152 @@ func TestUnmarshalConfigs(t *testing.T) {
 	}
 }
 
+func TestUnmarshalConfigsWithProfile(t *testing.T) {
+	defer chdirBack(t)
+	chdirToRoot(t)
+	useTrustedFiles(t)
+	testCases := map[string]struct {
+		profile       string
+		wantTitle     string
+		wantShortcuts int
+		wantErr       bool
+	}{
+		"base":      {"", "Mamy przykładowy tytuł UTF-8 😎", 8, false},
+		"ops":       {"ops", "[OPS] Launchee 7", 8, false},
+		"undefined": {"meetings", "Launchee", 0, true},
+	}
+
+	lctx.LoggerImpl = stub.LoggerStub{}
+	defer func() { ConfigPathImpl = systemAwareConfigPath{} }()
+	ConfigPathImpl = stub.ConfigPathValidStub{}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := UnmarshalConfigs(testCase.profile)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("UnmarshalConfigs(%q) = %v, want error %t", testCase.profile, err, testCase.wantErr)
+			}
+			if got.UI.Nav.Title != testCase.wantTitle || len(got.Shortcuts) != testCase.wantShortcuts {
+				t.Errorf("UnmarshalConfigs(%q) = %q with %d shortcuts, want %q with %d", testCase.profile,
+					got.UI.Nav.Title, len(got.Shortcuts), testCase.wantTitle, testCase.wantShortcuts)
+			}
+		})
+	}
+}
+
+func TestUnmarshalConfigsAppendsCommandArgs(t *testing.T) {
+	defer chdirBack(t)
+	chdirToRoot(t)
+	useTrustedFiles(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	defer func() { ConfigPathImpl = systemAwareConfigPath{} }()
+	ConfigPathImpl = stub.ConfigPathValidStub{}
+	got, err := UnmarshalConfigs("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Text", "Editor", "--new-window"}
+	for _, shortcut := range got.Shortcuts {
+		if shortcut.Name == "Text Editor" && !slices.Equal(shortcut.CommandArgs, want) {
+			t.Errorf("UnmarshalConfigs() = Command Args %q of Text Editor, want %q", shortcut.CommandArgs, want)
+		}
+	}
+}
+
+func TestUnmarshalConfigsLenient(t *testing.T) {
+	defer chdirBack(t)
+	chdirToRoot(t)
+	useTrustedFiles(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	defer func() { ConfigPathImpl = systemAwareConfigPath{} }()
+	ConfigPathImpl = stub.ConfigPathLenientStub{}
+	got, err := UnmarshalConfigs("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Shortcuts) != 1 || len(got.InvalidShortcuts) != 1 || got.InvalidShortcuts[0].Name != "Uninstalled" {
+		t.Errorf("UnmarshalConfigs() = %d shortcuts and invalid %v, want Uninstalled dropped", len(got.Shortcuts),
+			got.InvalidShortcuts)
+	}
+}
+
+func TestUnmarshalConfigsInheritsUrlSchemes(t *testing.T) {
+	defer chdirBack(t)
+	chdirToRoot(t)
+	useTrustedFiles(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	dir := t.TempDir()
+	systemConfigFile := filepath.Join(dir, "system.yml")
+	userConfigFile := filepath.Join(dir, "user.yml")
+	writeFile(t, systemConfigFile, []byte("urlSchemes: [\"ssh\"]\n"), 0o644)
+	writeFile(t, userConfigFile, []byte("shortcuts:\n  - name: \"Server\"\n    url: \"ssh://b\"\n"+
+		"    icon: \"internal/test/stub/stub_config/icons/default128.png\"\n"), 0o644)
+	defer func() { ConfigPathImpl = systemAwareConfigPath{} }()
+	ConfigPathImpl = customConfigPath{systemConfigPath: systemConfigFile, userConfigPath: userConfigFile}
+
+	got, err := UnmarshalConfigs("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Shortcuts) != 1 || got.Shortcuts[0].Url != "ssh://b" {
+		t.Errorf("UnmarshalConfigs() = %v, want the ssh Shortcut of the user config", got.Shortcuts)
+	}
+}
+
+func TestUnmarshalCustomConfigWithProfile(t *testing.T) {
+	defer chdirBack(t)
+	chdirToRoot(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	got, err := UnmarshalCustomConfig(stub.ConfigPathValidStub{}.GetSystemConfigPath(), "ops")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Profile != "ops" || len(got.Profiles) != 1 || got.UI.Nav.Title != "[OPS] Launchee 7" {
+		t.Errorf("UnmarshalCustomConfig() = profile %q of %v titled %q, want ops", got.Profile, got.Profiles, got.UI.Nav.Title)
+	}
+}
+
+func TestUnmarshalCustomConfigWithPolicy(t *testing.T) {
+	defer chdirBack(t)
+	chdirToRoot(t)
+	useTrustedFiles(t)
+	lctx.LoggerImpl = stub.LoggerStub{}
+	const icon = "    icon: \"internal/test/stub/stub_config/icons/default128.png\"\n"
+	dir := t.TempDir()
+	systemConfigFile := filepath.Join(dir, "system.yml")
+	customConfigFile := filepath.Join(dir, "custom.yml")
+	writeFile(t, customConfigFile, []byte("validation: \"lenient\"\nshortcuts:\n  - name: \"Shell\"\n"+
+		"    command: \"sh\"\n"+icon), 0o644)
+	defer func() { ConfigPathImpl = systemAwareConfigPath{} }()
+	ConfigPathImpl = customConfigPath{systemConfigPath: systemConfigFile}
+	testCases := map[string]struct {
+		systemConfig string
+		wantNames    []string
+	}{
+		"policy": {
+			"policy:\n  allowUserCommands: false\nshortcuts:\n  - name: \"Kiosk\"\n    url: \"https://example.com\"\n" + icon,
+			[]string{"Kiosk"},
+		},
+		"no policy": {"shortcuts:\n  - name: \"Kiosk\"\n    url: \"https://example.com\"\n" + icon, []string{"Shell"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			writeFile(t, systemConfigFile, []byte(testCase.systemConfig), 0o644)
+			got, err := UnmarshalCustomConfig(customConfigFile, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotNames []string
+			for _, shortcut := range got.Shortcuts {
+				gotNames = append(gotNames, shortcut.Name)
+			}
+			if !slices.Equal(gotNames, testCase.wantNames) {
+				t.Errorf("UnmarshalCustomConfig() = %v, want %v", gotNames, testCase.wantNames)
+			}
+		})
+	}
+}
+
 func chdirBack(t *testing.T) {
 	if err := os.Chdir(filepath.Join("internal", "config", "yaml")); err != nil {
 		t.Errorf("UnmarshalConfigs() = %v", err)
@@ -130,27 +280,37 @@ func TestSystemAwareConfigPath(t *testing.T) {
 func TestFindConfigFile(t *testing.T) {
 	testCases := map[string]struct {
 		input string
+		files []string
 		want  string
 	}{
-		"empty":      {"", ""},
-		"not-exists": {t.TempDir(), ""},
-		"yml":        {t.TempDir(), "launchee/launchee.yml"},
-		"yaml":       {t.TempDir(), "launchee/launchee.yaml"},
+		"empty":      {"", nil, ""},
+		"not-exists": {t.TempDir(), nil, ""},
+		"yml":        {t.TempDir(), []string{"launchee.yml"}, "launchee/launchee.yml"},
+		"yaml":       {t.TempDir(), []string{"launchee.yaml"}, "launchee/launchee.yaml"},
+		"toml":       {t.TempDir(), []string{"launchee.toml"}, "launchee/launchee.toml"},
+		"json":       {t.TempDir(), []string{"launchee.json"}, "launchee/launchee.json"},
+		"yml first":  {t.TempDir(), []string{"launchee.json", "launchee.toml", "launchee.yml"}, "launchee/launchee.yml"},
+		"toml first": {t.TempDir(), []string{"launchee.json", "launchee.toml"}, "launchee/launchee.toml"},
 	}
 
+	lctx.LoggerImpl = stub.LoggerStub{}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
-			var want string
-			if testCase.want != "" {
+			if len(testCase.files) != 0 {
 				configDir := filepath.Join(testCase.input, "launchee")
 				if err := os.Mkdir(configDir, 0755); err != nil {
 					t.Errorf("Mkdir() = %v", err)
 				}
-				want = filepath.Join(testCase.input, testCase.want)
-				if err := os.WriteFile(want, []byte{}, 0644); err != nil {
-					t.Errorf("WriteFile() = %v", err)
+				for _, file := range testCase.files {
+					if err := os.WriteFile(filepath.Join(configDir, file), []byte{}, 0644); err != nil {
+						t.Errorf("WriteFile() = %v", err)
+					}
 				}
 			}
+			var want string
+			if testCase.want != "" {
+				want = filepath.Join(testCase.input, testCase.want)
+			}
 			got := findConfigFile(testCase.input)
 			if got != want {
 				t.Errorf("findConfigFile(%q) = %q, want %q", testCase.input, got, want)
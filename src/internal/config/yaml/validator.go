@@ -17,17 +17,53 @@
 package yaml
 
 import (
+	"net"
+	"net/url"
 	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
 	"github.com/pkg/errors"
 )
 
 const maxIconSize = 1 << 20 // 1 MB
 
+const maxRecentItems = 20
+
+// Length limits of titles, names and labels.
+const (
+	minNameLength = 3
+	maxNameLength = 30
+)
+
+const (
+	urlSchemeHttp  = "http"
+	urlSchemeHttps = "https"
+)
+
+var sha256Regexp = regexp.MustCompile("^[0-9a-f]{64}$")
+
+var shortcutIdRegexp = regexp.MustCompile("^[A-Za-z0-9][A-Za-z0-9._-]{0,29}$")
+
+const (
+	validationStrict  = "strict"
+	validationLenient = "lenient"
+)
+
+// shortcutDiagnostic is the problem of a single shortcut, which the lenient validation drops instead of failing.
+type shortcutDiagnostic struct {
+	shortcut *shortcut
+	err      error
+}
+
 func validate(config *config) error {
 	if config == nil {
 		return nil
@@ -35,33 +71,347 @@ func validate(config *config) error {
 	if err := validateTitle(config); err != nil {
 		return err
 	}
+	if err := validateValidation(config); err != nil {
+		return err
+	}
+	if err := validateUrlSchemes(config); err != nil {
+		return err
+	}
+	if err := validateBrowsers(config); err != nil {
+		return err
+	}
+	if err := validateAutoHide(config); err != nil {
+		return err
+	}
+	if err := validateSort(config); err != nil {
+		return err
+	}
+	if err := validateRecent(config); err != nil {
+		return err
+	}
+	if err := validateRemote(config); err != nil {
+		return err
+	}
+	if err := validatePolicy(config); err != nil {
+		return err
+	}
+	if config.Behavior != nil {
+		if err := validateAfterLaunch(config.Behavior.AfterLaunch); err != nil {
+			return errors.WithMessage(err, "Behavior is invalid")
+		}
+	}
 	if err := validateShortcuts(config); err != nil {
 		return err
 	}
+	if err := validateProfiles(config); err != nil {
+		return err
+	}
 	return nil
 }
 
 func validateTitle(config *config) error {
 	titleLength := utf8.RuneCountInString(config.Title)
-	if titleLength != 0 && (titleLength < 3 || titleLength > 30) {
-		return errors.Errorf("Title \"%s\" must be between 3 and 30 characters long (got %d)", config.Title, titleLength)
+	if titleLength != 0 && (titleLength < minNameLength || titleLength > maxNameLength) {
+		return errors.Errorf("Title \"%s\" must be between %d and %d characters long (got %d)", config.Title, minNameLength,
+			maxNameLength, titleLength)
+	}
+	return nil
+}
+
+func validateValidation(config *config) error {
+	switch config.Validation {
+	case "", validationStrict, validationLenient:
+		return nil
+	default:
+		return errors.Errorf("Validation must be \"%s\" or \"%s\" (got \"%s\")", validationStrict, validationLenient,
+			config.Validation)
+	}
+}
+
+func validateUrlSchemes(config *config) error {
+	for _, urlScheme := range config.UrlSchemes {
+		if parsedUrl, err := url.Parse(urlScheme + ":"); err != nil || parsedUrl.Scheme != urlScheme {
+			return errors.Errorf("URL Scheme \"%s\" is not valid, e.g. \"ssh\" or \"mailto\"", urlScheme)
+		}
+	}
+	return nil
+}
+
+func validateBrowsers(config *config) error {
+	names := make(map[string]bool, len(config.Browsers))
+	for i, browser := range config.Browsers {
+		if browser == nil {
+			return errors.Errorf("Browser %d is empty", i+1)
+		}
+		if names[browser.Name] {
+			return errors.Errorf("Browser \"%s\" is defined more than once", browser.Name)
+		}
+		names[browser.Name] = true
+		if err := validateBrowser(browser); err != nil {
+			return errors.WithMessagef(err, "Browser %d is invalid", i+1)
+		}
+	}
+	return nil
+}
+
+func validateAutoHide(config *config) error {
+	if config.AutoHide == nil {
+		return nil
+	}
+	if config.AutoHide.Delay != "" {
+		if delay, err := time.ParseDuration(config.AutoHide.Delay); err != nil || delay <= 0 {
+			return errors.Errorf("Auto Hide Delay must be a positive duration, e.g. \"3s\" (got \"%s\")", config.AutoHide.Delay)
+		}
+	}
+	switch config.AutoHide.Mode {
+	case "", frontend.AutoHideModeStrip, frontend.AutoHideModeHide:
+		return nil
+	default:
+		return errors.Errorf("Auto Hide Mode must be \"%s\" or \"%s\" (got \"%s\")",
+			frontend.AutoHideModeStrip, frontend.AutoHideModeHide, config.AutoHide.Mode)
+	}
+}
+
+func validateSort(config *config) error {
+	switch config.Sort {
+	case "", sortConfig, sortMostUsed, sortRecent, sortAlphabetical:
+		return nil
+	default:
+		return errors.Errorf("Sort must be \"%s\", \"%s\", \"%s\" or \"%s\" (got \"%s\")", sortConfig, sortMostUsed,
+			sortRecent, sortAlphabetical, config.Sort)
+	}
+}
+
+func validateRecent(config *config) error {
+	if config.Recent == nil {
+		return nil
+	}
+	if config.Recent.Limit < 0 || config.Recent.Limit > maxRecentItems {
+		return errors.Errorf("Recent Limit must be between 1 and %d (got %d)", maxRecentItems, config.Recent.Limit)
+	}
+	for _, mimeType := range config.Recent.MimeTypes {
+		if _, err := path.Match(mimeType, ""); err != nil || !strings.Contains(mimeType, "/") {
+			return errors.Errorf("Recent MIME Type \"%s\" is not valid, e.g. \"text/plain\" or \"image/*\"", mimeType)
+		}
+	}
+	for i, application := range config.Recent.Applications {
+		if application == "" {
+			return errors.Errorf("Recent Application %d is empty", i+1)
+		}
+	}
+	return nil
+}
+
+func validateRemote(config *config) error {
+	if config.Remote == nil {
+		return nil
+	}
+	if parsedUrl, err := url.Parse(config.Remote.Url); err != nil ||
+		(parsedUrl.Scheme != urlSchemeHttp && parsedUrl.Scheme != urlSchemeHttps) || parsedUrl.Host == "" {
+		return errors.Errorf("Remote URL must be an http or https URL (got \"%s\")", config.Remote.Url)
+	}
+	if config.Remote.Sha256 != "" && !sha256Regexp.MatchString(config.Remote.Sha256) {
+		return errors.Errorf("Remote SHA-256 must be 64 hexadecimal characters (got \"%s\")", config.Remote.Sha256)
+	}
+	if config.Remote.Timeout != "" {
+		if timeout, err := time.ParseDuration(config.Remote.Timeout); err != nil || timeout <= 0 {
+			return errors.Errorf("Remote Timeout must be a positive duration, e.g. \"5s\" (got \"%s\")",
+				config.Remote.Timeout)
+		}
+	}
+	return nil
+}
+
+func validatePolicy(config *config) error {
+	if config.Policy == nil {
+		return nil
+	}
+	for i, name := range config.Policy.LockedShortcuts {
+		if name == "" {
+			return errors.Errorf("Locked Shortcut %d of Policy is empty", i+1)
+		}
+	}
+	for i, prefix := range config.Policy.AllowedCommandPrefixes {
+		if prefix == "" {
+			return errors.Errorf("Allowed Command Prefix %d of Policy is empty, which would allow any command", i+1)
+		}
+		if !filepath.IsAbs(prefix) {
+			return errors.Errorf("Allowed Command Prefix %d of Policy must be an absolute path (got \"%s\")", i+1, prefix)
+		}
+	}
+	return nil
+}
+
+func validateAfterLaunch(afterLaunch string) error {
+	switch afterLaunch {
+	case "", frontend.AfterLaunchKeep, frontend.AfterLaunchMinimise, frontend.AfterLaunchHide, frontend.AfterLaunchQuit:
+		return nil
+	default:
+		return errors.Errorf("After Launch must be \"%s\", \"%s\", \"%s\" or \"%s\" (got \"%s\")", frontend.AfterLaunchKeep,
+			frontend.AfterLaunchMinimise, frontend.AfterLaunchHide, frontend.AfterLaunchQuit, afterLaunch)
+	}
+}
+
+func validateBrowser(browser *browser) error {
+	browserShortcut := browser.toShortcut()
+	if err := validateShortcutName(browserShortcut); err != nil {
+		return err
+	}
+	if browser.Command == "" {
+		return errors.Errorf("Command of \"%s\" Browser is required", browser.Name)
+	}
+	if err := validateShortcutCommand(browserShortcut); err != nil {
+		return err
+	}
+	return validateShortcutCommandArgs(browserShortcut)
+}
+
+func validateProfiles(config *config) error {
+	names := make(map[string]bool, len(config.Profiles))
+	for i, profile := range config.Profiles {
+		if profile == nil {
+			return errors.Errorf("Profile %d is empty", i+1)
+		}
+		if names[profile.Name] {
+			return errors.Errorf("Profile \"%s\" is defined more than once", profile.Name)
+		}
+		names[profile.Name] = true
+		if err := validateProfile(profile, config); err != nil {
+			return errors.WithMessagef(err, "Profile %d is invalid", i+1)
+		}
+	}
+	return nil
+}
+
+// validateProfile validates the profile whose shortcuts may use the URL schemes and browsers of the parent config and
+// are validated the same way as its own.
+func validateProfile(profile *profile, parent *config) error {
+	nameLength := utf8.RuneCountInString(profile.Name)
+	if nameLength < minNameLength || nameLength > maxNameLength {
+		return errors.Errorf("Name of \"%s\" Profile must be between %d and %d characters long (got %d)", profile.Name,
+			minNameLength, maxNameLength, nameLength)
+	}
+	if err := validateTitle(&config{Title: profile.Title}); err != nil {
+		return err
+	}
+	profileConfig := &config{
+		UrlSchemes:          parent.UrlSchemes,
+		Browsers:            parent.Browsers,
+		Validation:          parent.Validation,
+		Shortcuts:           profile.Shortcuts,
+		InheritedUrlSchemes: parent.InheritedUrlSchemes,
+	}
+	if err := validateShortcuts(profileConfig); err != nil {
+		return err
+	}
+	profile.Shortcuts = profileConfig.Shortcuts
+	for _, invalid := range profileConfig.Invalid {
+		invalid.Profile = profile.Name
+		parent.Invalid = append(parent.Invalid, invalid)
 	}
 	return nil
 }
 
+// validateShortcuts fails on the first invalid shortcut, unless the validation is lenient. Then the invalid shortcuts
+// are dropped and recorded, so that the rest of the dock keeps working.
 func validateShortcuts(config *config) error {
+	return applyDiagnostics(config, diagnoseShortcuts(config))
+}
+
+// validateMergedShortcuts fails on the shortcut of the merged config whose id, or name when it has none, is already
+// used by another one, or whose browser is neither a merged browser nor a valid command, unless the validation is
+// lenient. Then the shortcut is dropped and recorded.
+func validateMergedShortcuts(config *config) error {
+	if config == nil {
+		return nil
+	}
+	diagnostics := diagnoseDuplicateKeys(config.Shortcuts)
 	for _, shortcut := range config.Shortcuts {
-		if err := validateShortcut(shortcut); err != nil {
-			return err
+		isDiagnosed := slices.ContainsFunc(diagnostics, func(diagnostic *shortcutDiagnostic) bool {
+			return diagnostic.shortcut == shortcut
+		})
+		if err := validateMergedShortcutBrowser(shortcut, config.Browsers); err != nil && !isDiagnosed {
+			diagnostics = append(diagnostics, &shortcutDiagnostic{shortcut, err})
 		}
 	}
+	return applyDiagnostics(config, diagnostics)
+}
+
+// validateMergedShortcutBrowser resolves the browser of the merged shortcut, which is run as a command unless it names
+// one of the merged browsers.
+func validateMergedShortcutBrowser(shortcut *shortcut, browsers []*browser) error {
+	if shortcut.Browser == "" || findBrowser(browsers, shortcut.Browser) != nil {
+		return nil
+	}
+	command, _, err := splitBrowserTemplate(shortcut.Browser)
+	if err != nil {
+		return errors.Errorf("Browser of \"%s\" Shortcut cannot be split the way a shell would: %v (got \"%s\")",
+			shortcut.Name, err, shortcut.Browser)
+	}
+	if err = validateShortcutCommand((&browser{Name: shortcut.Name, Command: command}).toShortcut()); err != nil {
+		return errors.WithMessagef(err, "Browser of \"%s\" Shortcut is neither a defined Browser nor a valid Command",
+			shortcut.Name)
+	}
 	return nil
 }
 
-func validateShortcut(shortcut *shortcut) error {
+func applyDiagnostics(config *config, diagnostics []*shortcutDiagnostic) error {
+	if len(diagnostics) == 0 {
+		return nil
+	}
+	if config.Validation != validationLenient {
+		return diagnostics[0].err
+	}
+	for _, diagnostic := range diagnostics {
+		lctx.LogErrorf("Skipping invalid \"%s\" Shortcut: %v", diagnostic.shortcut.Name, diagnostic.err)
+		config.Shortcuts = slices.DeleteFunc(config.Shortcuts, func(shortcut *shortcut) bool {
+			return shortcut == diagnostic.shortcut
+		})
+		config.Invalid = append(config.Invalid, &frontend.InvalidShortcut{
+			Name:    diagnostic.shortcut.Name,
+			Problem: diagnostic.err.Error(),
+		})
+	}
+	return nil
+}
+
+// diagnoseShortcuts returns the problem of each invalid shortcut of the config.
+func diagnoseShortcuts(config *config) []*shortcutDiagnostic {
+	var diagnostics []*shortcutDiagnostic
+	urlSchemes := config.allowedUrlSchemes()
+	var withIds []*shortcut
+	for _, shortcut := range config.Shortcuts {
+		if err := validateShortcut(shortcut, urlSchemes, config.Browsers); err != nil {
+			diagnostics = append(diagnostics, &shortcutDiagnostic{shortcut, err})
+		} else if shortcut.Id != "" && !shortcut.isPatchMode() {
+			withIds = append(withIds, shortcut)
+		}
+	}
+	return append(diagnostics, diagnoseDuplicateKeys(withIds)...)
+}
+
+// diagnoseDuplicateKeys returns the shortcuts whose id, or name when they have none, is already used by another one.
+func diagnoseDuplicateKeys(shortcuts []*shortcut) []*shortcutDiagnostic {
+	var diagnostics []*shortcutDiagnostic
+	keys := make(map[string]*shortcut)
+	for _, shortcut := range shortcuts {
+		if other, found := keys[shortcut.key()]; found {
+			diagnostics = append(diagnostics, &shortcutDiagnostic{shortcut, errors.Errorf(
+				"Id \"%s\" of \"%s\" Shortcut is already used by \"%s\" Shortcut", shortcut.key(), shortcut.Name, other.Name)})
+		} else {
+			keys[shortcut.key()] = shortcut
+		}
+	}
+	return diagnostics
+}
+
+func validateShortcut(shortcut *shortcut, urlSchemes []string, browsers []*browser) error {
 	if err := validateShortcutName(shortcut); err != nil {
 		return err
 	}
+	if err := validateShortcutId(shortcut); err != nil {
+		return err
+	}
 	if err := validateShortcutPatch(shortcut); err != nil {
 		return err
 	}
@@ -77,7 +427,22 @@ func validateShortcut(shortcut *shortcut) error {
 	if err := validateShortcutCommandArgs(shortcut); err != nil {
 		return err
 	}
-	if err := validateShortcutUrl(shortcut); err != nil {
+	if err := validateShortcutUrl(shortcut, urlSchemes); err != nil {
+		return err
+	}
+	if err := validateShortcutPath(shortcut); err != nil {
+		return err
+	}
+	if err := validateShortcutBrowser(shortcut, browsers); err != nil {
+		return err
+	}
+	if err := validateAfterLaunch(shortcut.AfterLaunch); err != nil {
+		return errors.WithMessagef(err, "\"%s\" Shortcut is invalid", shortcut.Name)
+	}
+	if err := validateShortcutActions(shortcut, urlSchemes); err != nil {
+		return err
+	}
+	if err := validateShortcutMenu(shortcut, urlSchemes); err != nil {
 		return err
 	}
 	return nil
@@ -85,16 +450,52 @@ func validateShortcut(shortcut *shortcut) error {
 
 func validateShortcutName(shortcut *shortcut) error {
 	nameLength := utf8.RuneCountInString(shortcut.Name)
-	if nameLength < 3 || nameLength > 30 {
-		return errors.Errorf("Name of \"%s\" Shortcut must be between 3 and 30 characters long (got %d)", shortcut.Name, nameLength)
+	if nameLength < minNameLength || nameLength > maxNameLength {
+		return errors.Errorf("Name of \"%s\" Shortcut must be between %d and %d characters long (got %d)", shortcut.Name,
+			minNameLength, maxNameLength, nameLength)
+	}
+	return nil
+}
+
+func validateShortcutId(shortcut *shortcut) error {
+	if shortcut.Id != "" && !shortcutIdRegexp.MatchString(shortcut.Id) {
+		return errors.Errorf("Id of \"%s\" Shortcut must be 1 to 30 letters, digits, dots, underscores or hyphens, "+
+			"starting with a letter or digit (got \"%s\")", shortcut.Name, shortcut.Id)
 	}
 	return nil
 }
 
 func validateShortcutPatch(shortcut *shortcut) error {
-	if shortcut.Patch != "" && shortcut.Patch != patchReplace && shortcut.Patch != patchMerge && shortcut.Patch != patchDelete {
-		return errors.Errorf("Patch of \"%s\" Shortcut must be either \"%s\", \"%s\" or \"%s\" (got \"%s\")",
-			shortcut.Name, patchReplace, patchMerge, patchDelete, shortcut.Patch)
+	switch shortcut.Patch {
+	case "", patchReplace, patchMerge, patchDelete, patchMove, patchRename:
+	default:
+		return errors.Errorf("Patch of \"%s\" Shortcut must be either \"%s\", \"%s\", \"%s\", \"%s\" or \"%s\" (got \"%s\")",
+			shortcut.Name, patchReplace, patchMerge, patchDelete, patchMove, patchRename, shortcut.Patch)
+	}
+	placements := shortcut.placements()
+	if len(placements) > 1 {
+		return errors.Errorf("\"%s\" Shortcut cannot have more than one of Before, After or Position set - choose one (got %s)",
+			shortcut.Name, strings.Join(placements, " and "))
+	}
+	if shortcut.Position < 0 {
+		return errors.Errorf("Position of \"%s\" Shortcut must be at least 1 (got %d)", shortcut.Name, shortcut.Position)
+	}
+	if shortcut.Patch == patchDelete && len(placements) != 0 {
+		return errors.Errorf("Deleted \"%s\" Shortcut cannot have Before, After or Position set", shortcut.Name)
+	}
+	if shortcut.Patch == patchMove && len(placements) == 0 {
+		return errors.Errorf("Moved \"%s\" Shortcut must have Before, After or Position set", shortcut.Name)
+	}
+	if shortcut.Patch != patchRename {
+		if shortcut.NewName != "" {
+			return errors.Errorf("New Name of \"%s\" Shortcut requires Patch \"%s\"", shortcut.Name, patchRename)
+		}
+		return nil
+	}
+	newNameLength := utf8.RuneCountInString(shortcut.NewName)
+	if newNameLength < minNameLength || newNameLength > maxNameLength {
+		return errors.Errorf("New Name of \"%s\" Shortcut must be between %d and %d characters long (got %d)",
+			shortcut.Name, minNameLength, maxNameLength, newNameLength)
 	}
 	return nil
 }
@@ -129,12 +530,13 @@ func isFileLargerThan1MB(path string) bool {
 }
 
 func validateShortcutCommandAndUrl(shortcut *shortcut) error {
-	if !shortcut.isPatchMode() && shortcut.Command == "" && shortcut.Url == "" {
-		return errors.Errorf("Either Command or URL of \"%s\" Shortcut must be set", shortcut.Name)
+	targets := shortcut.targets()
+	if !shortcut.isPatchMode() && len(targets) == 0 {
+		return errors.Errorf("Either Command, URL, Path or Actions of \"%s\" Shortcut must be set", shortcut.Name)
 	}
-	if shortcut.Command != "" && shortcut.Url != "" {
-		return errors.Errorf("\"%s\" Shortcut cannot have both a Command and a URL set - choose one (got Command: \"%s\" and URL: \"%s\")",
-			shortcut.Name, shortcut.Command, shortcut.Url)
+	if len(targets) > 1 {
+		return errors.Errorf("\"%s\" Shortcut cannot have more than one of Command, URL, Path or Actions set - choose one (got %s)",
+			shortcut.Name, strings.Join(targets, " and "))
 	}
 	return nil
 }
@@ -152,18 +554,163 @@ func isExec(command string) bool {
 }
 
 func validateShortcutCommandArgs(shortcut *shortcut) error {
-	if shortcut.CommandArgs != "" && shortcut.Command == "" {
+	if !shortcut.CommandArgs.isSet() {
+		return nil
+	}
+	if shortcut.CommandArgs.isPatch() {
+		if shortcut.Patch != patchMerge {
+			return errors.Errorf("Command Args of \"%s\" Shortcut can only use \"%s\" and \"%s\" with $patch \"%s\" (got \"%s\")",
+				shortcut.Name, commandArgsAppend, commandArgsPrepend, patchMerge, shortcut.CommandArgs)
+		}
+		return nil
+	}
+	if shortcut.Command == "" {
 		return errors.Errorf("Command Args of \"%s\" Shortcut not allowed without a Command (got \"%s\")", shortcut.Name, shortcut.CommandArgs)
 	}
+	if _, err := shortcut.CommandArgs.split(); err != nil {
+		return errors.Errorf("Command Args of \"%s\" Shortcut cannot be split the way a shell would: %v (got \"%s\")",
+			shortcut.Name, err, shortcut.CommandArgs)
+	}
 	return nil
 }
 
-func validateShortcutUrl(shortcut *shortcut) error {
-	if shortcut.Url != "" {
-		shortcutUrl := strings.ToLower(shortcut.Url)
-		if !strings.HasPrefix(shortcutUrl, "https://") && !strings.HasPrefix(shortcutUrl, "http://") {
-			return errors.Errorf("URL of \"%s\" Shortcut must start with \"http://\" or \"https://\" (got \"%s\")", shortcut.Name, shortcut.Url)
+func validateShortcutUrl(shortcut *shortcut, urlSchemes []string) error {
+	if shortcut.Url != "" && !isAllowedUrl(shortcut.Url, urlSchemes) {
+		return errors.Errorf("URL of \"%s\" Shortcut must start with one of the allowed schemes: %s (got \"%s\")",
+			shortcut.Name, toUrlPrefixes(urlSchemes), shortcut.Url)
+	}
+	return nil
+}
+
+// Checks if the URL uses one of the allowed schemes. Web URLs also need the "//" authority part.
+func isAllowedUrl(shortcutUrl string, urlSchemes []string) bool {
+	for _, urlScheme := range urlSchemes {
+		prefix := urlScheme + ":"
+		if urlScheme == urlSchemeHttp || urlScheme == urlSchemeHttps {
+			prefix += "//"
 		}
+		if len(shortcutUrl) > len(prefix) && strings.EqualFold(shortcutUrl[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func toUrlPrefixes(urlSchemes []string) string {
+	prefixes := make([]string, len(urlSchemes))
+	for i, urlScheme := range urlSchemes {
+		prefixes[i] = "\"" + urlScheme + ":\""
+	}
+	return strings.Join(prefixes, ", ")
+}
+
+func validateShortcutPath(shortcut *shortcut) error {
+	if shortcut.Path != "" && !fileExists(shortcut.Path) {
+		return errors.Errorf("Path of \"%s\" Shortcut does not exist under: \"%s\"", shortcut.Name, shortcut.Path)
 	}
 	return nil
 }
+
+// A single word that is not a browser defined in this config may be defined in the other one, so only command
+// templates are checked here. validateMergedShortcuts resolves the single words once the configs are merged.
+func validateShortcutBrowser(shortcut *shortcut, browsers []*browser) error {
+	if shortcut.Browser == "" {
+		return nil
+	}
+	if shortcut.Url == "" && !shortcut.isPatchMode() {
+		return errors.Errorf("Browser of \"%s\" Shortcut not allowed without a URL (got \"%s\")", shortcut.Name, shortcut.Browser)
+	}
+	if findBrowser(browsers, shortcut.Browser) != nil || !strings.ContainsAny(shortcut.Browser, " \t") {
+		return nil
+	}
+	command, _, err := splitBrowserTemplate(shortcut.Browser)
+	if err != nil {
+		return errors.Errorf("Browser of \"%s\" Shortcut cannot be split the way a shell would: %v (got \"%s\")",
+			shortcut.Name, err, shortcut.Browser)
+	}
+	if err = validateShortcutCommand((&browser{Name: shortcut.Name, Command: command}).toShortcut()); err != nil {
+		return errors.WithMessagef(err, "Browser of \"%s\" Shortcut is invalid", shortcut.Name)
+	}
+	return nil
+}
+
+func validateShortcutActions(shortcut *shortcut, urlSchemes []string) error {
+	for i, action := range shortcut.Actions {
+		if err := validateShortcutAction(shortcut.Name, i, action, urlSchemes); err != nil {
+			return errors.WithMessagef(err, "Action %d of \"%s\" Shortcut is invalid", i+1, shortcut.Name)
+		}
+	}
+	return nil
+}
+
+func validateShortcutAction(name string, i int, action *action, urlSchemes []string) error {
+	if action == nil {
+		return errors.New("Action must not be empty")
+	}
+	if err := validateCommandOrUrl(action.toShortcut(name), urlSchemes); err != nil {
+		return err
+	}
+	return validateActionWaitFor(i, action)
+}
+
+// Validates the Command, URL or Path of a shortcut-like step (an action or a menu item) the same way as of a shortcut.
+func validateCommandOrUrl(stepShortcut *shortcut, urlSchemes []string) error {
+	for _, validateFunc := range []func(*shortcut) error{
+		validateShortcutCommandAndUrl,
+		validateShortcutCommand,
+		validateShortcutCommandArgs,
+		func(stepShortcut *shortcut) error {
+			return validateShortcutUrl(stepShortcut, urlSchemes)
+		},
+		validateShortcutPath,
+	} {
+		if err := validateFunc(stepShortcut); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateActionWaitFor(i int, action *action) error {
+	if i == 0 && action.Parallel {
+		return errors.New("The first Action cannot run in parallel")
+	}
+	if action.WaitFor == nil {
+		return nil
+	}
+	if action.WaitFor.Exit && (i == 0 || action.Parallel) {
+		return errors.New("Wait For Exit requires a previous Action and cannot be combined with Parallel")
+	}
+	if action.WaitFor.Delay != "" {
+		if delay, err := time.ParseDuration(action.WaitFor.Delay); err != nil || delay < 0 {
+			return errors.Errorf("Wait For Delay must be a non-negative duration, e.g. \"2s\" (got \"%s\")", action.WaitFor.Delay)
+		}
+	}
+	if action.WaitFor.Port != "" {
+		if _, _, err := net.SplitHostPort(action.WaitFor.Port); err != nil {
+			return errors.Errorf("Wait For Port must be in \"host:port\" format, e.g. \"localhost:8080\" (got \"%s\")", action.WaitFor.Port)
+		}
+	}
+	return nil
+}
+
+func validateShortcutMenu(shortcut *shortcut, urlSchemes []string) error {
+	for i, menuItem := range shortcut.Menu {
+		if err := validateShortcutMenuItem(shortcut.Name, menuItem, urlSchemes); err != nil {
+			return errors.WithMessagef(err, "Menu Item %d of \"%s\" Shortcut is invalid", i+1, shortcut.Name)
+		}
+	}
+	return nil
+}
+
+func validateShortcutMenuItem(name string, menuItem *menuItem, urlSchemes []string) error {
+	if menuItem == nil {
+		return errors.New("Menu Item must not be empty")
+	}
+	labelLength := utf8.RuneCountInString(menuItem.Label)
+	if labelLength < minNameLength || labelLength > maxNameLength {
+		return errors.Errorf("Label \"%s\" must be between %d and %d characters long (got %d)", menuItem.Label,
+			minNameLength, maxNameLength, labelLength)
+	}
+	return validateCommandOrUrl(menuItem.toShortcut(name), urlSchemes)
+}
@@ -19,7 +19,13 @@ package yaml
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
 )
 
 func TestValidate(t *testing.T) {
@@ -32,6 +38,42 @@ func TestValidate(t *testing.T) {
 			validConfig.Title = "Te"
 			return validConfig
 		}, false},
+		"invalid auto hide": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.AutoHide = &autoHide{Mode: "fade"}
+			return validConfig
+		}, false},
+		"invalid recent": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Recent = &recent{Limit: -1}
+			return validConfig
+		}, false},
+		"invalid validation": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Validation = "loose"
+			return validConfig
+		}, false},
+		"lenient invalid shortcut icon": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Validation = "lenient"
+			validConfig.Shortcuts[2].Icon = "not-exists.png"
+			return validConfig
+		}, true},
+		"invalid sort": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Sort = "random"
+			return validConfig
+		}, false},
+		"invalid behavior": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Behavior = &behavior{AfterLaunch: "close"}
+			return validConfig
+		}, false},
+		"invalid shortcut after launch": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Shortcuts[0].AfterLaunch = "close"
+			return validConfig
+		}, false},
 		"invalid shortcut name": {func() *config {
 			validConfig := newValidConfig()
 			validConfig.Shortcuts[0].Name = "Te"
@@ -61,7 +103,7 @@ func TestValidate(t *testing.T) {
 		"invalid shortcut command args": {func() *config {
 			validConfig := newValidConfig()
 			validConfig.Shortcuts[2].Command = ""
-			validConfig.Shortcuts[2].CommandArgs = "-d /tmp/dummy -f foo.txt"
+			validConfig.Shortcuts[2].CommandArgs = &commandArgs{Line: "-d /tmp/dummy -f foo.txt"}
 			validConfig.Shortcuts[2].Patch = "merge"
 			return validConfig
 		}, false},
@@ -71,6 +113,53 @@ func TestValidate(t *testing.T) {
 			validConfig.Shortcuts[0].Url = "www.example.com"
 			return validConfig
 		}, false},
+		"invalid shortcut actions": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Shortcuts[1].Command = ""
+			validConfig.Shortcuts[1].Actions = []*action{{Command: "invalid"}}
+			return validConfig
+		}, false},
+		"invalid browsers": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Browsers = []*browser{{Name: "Work", Command: "echoo"}}
+			return validConfig
+		}, false},
+		"invalid shortcut browser": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Shortcuts[0].Browser = "Work"
+			return validConfig
+		}, false},
+		"valid shortcut browser": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Browsers = []*browser{{Name: "Work", Command: "echo", CommandArgs: &commandArgs{Line: "-P work {url}"}}}
+			validConfig.Shortcuts[0].Command = ""
+			validConfig.Shortcuts[0].Url = "https://example.com"
+			validConfig.Shortcuts[0].Browser = "Work"
+			return validConfig
+		}, true},
+		"invalid url schemes": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.UrlSchemes = []string{"ssh://"}
+			return validConfig
+		}, false},
+		"invalid shortcut path": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Shortcuts[0].Command = ""
+			validConfig.Shortcuts[0].Path = "not-exists"
+			return validConfig
+		}, false},
+		"valid custom url scheme": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.UrlSchemes = []string{"ssh"}
+			validConfig.Shortcuts[0].Command = ""
+			validConfig.Shortcuts[0].Url = "ssh://dev@example.com"
+			return validConfig
+		}, true},
+		"invalid shortcut menu": {func() *config {
+			validConfig := newValidConfig()
+			validConfig.Shortcuts[2].Menu = []*menuItem{{Label: "Label", Command: "echo", Url: "https://example.com"}}
+			return validConfig
+		}, false},
 		"nil": {func() *config {
 			return nil
 		}, true},
@@ -154,6 +243,106 @@ func TestValidateShortcutName(t *testing.T) {
 	}
 }
 
+func TestValidateShortcutId(t *testing.T) {
+	testCases := map[string]struct {
+		in   string
+		want bool
+	}{
+		"empty":           {"", true},
+		"1 char":          {"t", true},
+		"dotted":          {"org.gnome.Terminal", true},
+		"30 chars":        {"terminal-terminal-terminal_123", true},
+		"31 chars":        {"terminal-terminal-terminal_1234", false},
+		"space":           {"text editor", false},
+		"leading hyphen":  {"-terminal", false},
+		"non-ascii":       {"terminál", false},
+		"path separators": {"../terminal", false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateShortcutId(&shortcut{Id: testCase.in, Name: "Terminal"})
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateShortcutId(%q) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateShortcutIdsUnique(t *testing.T) {
+	in := newValidConfig()
+	in.Shortcuts[0].Id = "test"
+	in.Shortcuts[1].Id = "test"
+	want := "Id \"test\" of \"Test2\" Shortcut is already used by \"Test1\" Shortcut"
+	if err := validate(in); err == nil || err.Error() != want {
+		t.Errorf("validate() = %v, want %q", err, want)
+	}
+
+	in.Shortcuts[1].Patch = patchMerge
+	if err := validate(in); err != nil {
+		t.Errorf("validate() = %v, want nil for the patch of the same id", err)
+	}
+}
+
+func TestValidateMergedShortcuts(t *testing.T) {
+	testCases := map[string]struct {
+		validation  string
+		wantErr     bool
+		wantNames   []string
+		wantInvalid int
+	}{
+		"strict":  {"", true, []string{"Terminal", "term"}, 0},
+		"lenient": {validationLenient, false, []string{"Terminal"}, 1},
+	}
+
+	lctx.LoggerImpl = stub.LoggerStub{}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			in := &config{Validation: testCase.validation, Shortcuts: []*shortcut{
+				{Id: "term", Name: "Terminal"},
+				{Name: "term"},
+			}}
+			err := validateMergedShortcuts(in)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("validateMergedShortcuts() = %v, want error %t", err, testCase.wantErr)
+			}
+			var gotNames []string
+			for _, shortcut := range in.Shortcuts {
+				gotNames = append(gotNames, shortcut.Name)
+			}
+			if diff := cmp.Diff(testCase.wantNames, gotNames); diff != "" || len(in.Invalid) != testCase.wantInvalid {
+				t.Errorf("validateMergedShortcuts() = %v with %d invalid, want %v with %d", gotNames, len(in.Invalid),
+					testCase.wantNames, testCase.wantInvalid)
+			}
+		})
+	}
+}
+
+func TestValidateMergedShortcutBrowser(t *testing.T) {
+	browsers := []*browser{{Name: "Work", Command: "/usr/bin/firefox"}}
+	testCases := map[string]struct {
+		browser string
+		wantErr bool
+	}{
+		"none":             {"", false},
+		"merged browser":   {"Work", false},
+		"command":          {"sh", false},
+		"command template": {"sh -c {url}", false},
+		"undefined":        {"frefox", true},
+		"invalid template": {"frefox --new-window {url}", true},
+		"unbalanced quote": {"'sh", true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateMergedShortcutBrowser(&shortcut{Name: "Weather", Browser: testCase.browser}, browsers)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("validateMergedShortcutBrowser(%q) = %v, want error %t", testCase.browser, err, testCase.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateShortcutPatch(t *testing.T) {
 	testCases := map[string]struct {
 		in   *shortcut
@@ -164,6 +353,21 @@ func TestValidateShortcutPatch(t *testing.T) {
 		"merge":   {&shortcut{Patch: "merge"}, true},
 		"delete":  {&shortcut{Patch: "delete"}, true},
 		"other":   {&shortcut{Patch: "other"}, false},
+
+		"move before":             {&shortcut{Patch: "move", Before: "Firefox"}, true},
+		"move after":              {&shortcut{Patch: "move", After: "Firefox"}, true},
+		"move to position":        {&shortcut{Patch: "move", Position: 1}, true},
+		"move nowhere":            {&shortcut{Patch: "move"}, false},
+		"move before and after":   {&shortcut{Patch: "move", Before: "Firefox", After: "Terminal"}, false},
+		"negative position":       {&shortcut{Patch: "move", Position: -1}, false},
+		"add at position":         {&shortcut{Position: 2}, true},
+		"merge after":             {&shortcut{Patch: "merge", After: "Firefox"}, true},
+		"delete after":            {&shortcut{Patch: "delete", After: "Firefox"}, false},
+		"rename":                  {&shortcut{Patch: "rename", NewName: "Browser"}, true},
+		"rename and move":         {&shortcut{Patch: "rename", NewName: "Browser", Position: 1}, true},
+		"rename without new name": {&shortcut{Patch: "rename"}, false},
+		"rename to a short name":  {&shortcut{Patch: "rename", NewName: "Br"}, false},
+		"new name without rename": {&shortcut{Patch: "merge", NewName: "Browser"}, false},
 	}
 
 	for name, testCase := range testCases {
@@ -234,6 +438,12 @@ func TestValidateShortcutCommandAndUrl(t *testing.T) {
 		"command not empty":             {&shortcut{Command: "echo", Url: ""}, true},
 		"url not empty":                 {&shortcut{Command: "", Url: "https://example.com"}, true},
 		"both not empty":                {&shortcut{Command: "echo", Url: "https://example.com"}, false},
+		"actions only":                  {&shortcut{Actions: []*action{{Command: "echo"}}}, true},
+		"path only":                     {&shortcut{Path: "validator.go"}, true},
+		"path with command":             {&shortcut{Command: "echo", Path: "validator.go"}, false},
+		"path with url":                 {&shortcut{Url: "https://example.com", Path: "validator.go"}, false},
+		"actions with command":          {&shortcut{Command: "echo", Actions: []*action{{Command: "echo"}}}, false},
+		"actions with url":              {&shortcut{Url: "https://example.com", Actions: []*action{{Command: "echo"}}}, false},
 	}
 
 	for name, testCase := range testCases {
@@ -284,10 +494,14 @@ func TestValidateShortcutCommandArgs(t *testing.T) {
 		in   *shortcut
 		want bool
 	}{
-		"empty without command": {&shortcut{Command: "", CommandArgs: ""}, true},
-		"empty with command":    {&shortcut{Command: "command", CommandArgs: ""}, true},
-		"invalid":               {&shortcut{Command: "", CommandArgs: "-d /tmp/dummy -f foo.txt"}, false},
-		"valid":                 {&shortcut{Command: "command", CommandArgs: "-d /tmp/dummy -f foo.txt"}, true},
+		"empty without command": {&shortcut{Command: "", CommandArgs: &commandArgs{Line: ""}}, true},
+		"empty with command":    {&shortcut{Command: "command", CommandArgs: &commandArgs{Line: ""}}, true},
+		"invalid":               {&shortcut{Command: "", CommandArgs: &commandArgs{Line: "-d /tmp/dummy -f foo.txt"}}, false},
+		"valid":                 {&shortcut{Command: "command", CommandArgs: &commandArgs{Line: "-d /tmp/dummy -f foo.txt"}}, true},
+		"unbalanced quote":      {&shortcut{Command: "command", CommandArgs: &commandArgs{Line: "-s \"space test"}}, false},
+		"list":                  {&shortcut{Command: "command", CommandArgs: &commandArgs{List: []string{"-s", "\"space"}}}, true},
+		"append in merge":       {&shortcut{Patch: "merge", CommandArgs: &commandArgs{Append: []string{"-v"}}}, true},
+		"append in replace":     {&shortcut{Command: "command", CommandArgs: &commandArgs{Append: []string{"-v"}}}, false},
 	}
 
 	for name, testCase := range testCases {
@@ -301,26 +515,430 @@ func TestValidateShortcutCommandArgs(t *testing.T) {
 }
 
 func TestValidateShortcutUrl(t *testing.T) {
+	defaultUrlSchemes := (&config{}).allowedUrlSchemes()
+	customUrlSchemes := (&config{UrlSchemes: []string{"ssh", "vscode", "mailto", "file"}}).allowedUrlSchemes()
+
+	testCases := map[string]struct {
+		in         *shortcut
+		urlSchemes []string
+		want       bool
+	}{
+		"empty":                {&shortcut{Url: ""}, defaultUrlSchemes, true}, // We can have other actions
+		"invalid":              {&shortcut{Url: "invalid"}, defaultUrlSchemes, false},
+		"invalid www":          {&shortcut{Url: "www.example.com"}, defaultUrlSchemes, false},
+		"invalid http":         {&shortcut{Url: "http:/example.com"}, defaultUrlSchemes, false},
+		"invalid http only":    {&shortcut{Url: "http://"}, defaultUrlSchemes, false},
+		"valid http":           {&shortcut{Url: "http://example.com"}, defaultUrlSchemes, true},
+		"valid http www":       {&shortcut{Url: "http://www.example.com"}, defaultUrlSchemes, true},
+		"valid http uppercase": {&shortcut{Url: "HTTP://example.com"}, defaultUrlSchemes, true},
+		"invalid https":        {&shortcut{Url: "https:/example.com"}, defaultUrlSchemes, false},
+		"valid https":          {&shortcut{Url: "https://example.com"}, defaultUrlSchemes, true},
+		"valid https www":      {&shortcut{Url: "https://www.example.com"}, defaultUrlSchemes, true},
+		"not allowed ssh":      {&shortcut{Url: "ssh://dev@example.com"}, defaultUrlSchemes, false},
+		"not allowed mailto":   {&shortcut{Url: "mailto:dev@example.com"}, defaultUrlSchemes, false},
+		"allowed ssh":          {&shortcut{Url: "ssh://dev@example.com"}, customUrlSchemes, true},
+		"allowed vscode":       {&shortcut{Url: "vscode://file/tmp/launchee.yml"}, customUrlSchemes, true},
+		"allowed mailto":       {&shortcut{Url: "mailto:dev@example.com"}, customUrlSchemes, true},
+		"allowed file":         {&shortcut{Url: "file:///tmp"}, customUrlSchemes, true},
+		"allowed scheme only":  {&shortcut{Url: "mailto:"}, customUrlSchemes, false},
+		"allowed https":        {&shortcut{Url: "https://example.com"}, customUrlSchemes, true},
+		"no schemes":           {&shortcut{Url: "https://example.com"}, nil, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateShortcutUrl(testCase.in, testCase.urlSchemes)
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateShortcutUrl(%q) = %t, want %t", testCase.in.Url, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateUrlSchemes(t *testing.T) {
+	testCases := map[string]struct {
+		in   []string
+		want bool
+	}{
+		"empty":        {nil, true},
+		"valid":        {[]string{"ssh", "vscode", "mailto", "file", "web+launchee"}, true},
+		"empty scheme": {[]string{""}, false},
+		"with colon":   {[]string{"ssh:"}, false},
+		"with slashes": {[]string{"ssh://"}, false},
+		"digit first":  {[]string{"1ssh"}, false},
+		"with space":   {[]string{"my scheme"}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateUrlSchemes(&config{UrlSchemes: testCase.in})
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateUrlSchemes(%q) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateAutoHide(t *testing.T) {
+	testCases := map[string]struct {
+		in   *autoHide
+		want bool
+	}{
+		"nil":            {nil, true},
+		"empty":          {&autoHide{}, true},
+		"valid":          {&autoHide{Delay: "1m30s", Mode: "hide"}, true},
+		"strip":          {&autoHide{Mode: "strip"}, true},
+		"invalid delay":  {&autoHide{Delay: "soon"}, false},
+		"zero delay":     {&autoHide{Delay: "0s"}, false},
+		"negative delay": {&autoHide{Delay: "-1s"}, false},
+		"invalid mode":   {&autoHide{Mode: "fade"}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateAutoHide(&config{AutoHide: testCase.in})
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateAutoHide(%+v) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateRemote(t *testing.T) {
+	checksum := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	testCases := map[string]struct {
+		in   *remote
+		want bool
+	}{
+		"nil":              {nil, true},
+		"valid":            {&remote{Url: "https://example.com/launchee.yml", Sha256: checksum, Timeout: "3s"}, true},
+		"http":             {&remote{Url: "http://intranet/launchee.yml"}, true},
+		"empty":            {&remote{}, false},
+		"not http":         {&remote{Url: "ftp://example.com/launchee.yml"}, false},
+		"no host":          {&remote{Url: "https:///launchee.yml"}, false},
+		"short checksum":   {&remote{Url: "https://example.com", Sha256: checksum[1:]}, false},
+		"invalid checksum": {&remote{Url: "https://example.com", Sha256: "z" + checksum[1:]}, false},
+		"invalid timeout":  {&remote{Url: "https://example.com", Timeout: "soon"}, false},
+		"zero timeout":     {&remote{Url: "https://example.com", Timeout: "0s"}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateRemote(&config{Remote: testCase.in})
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateRemote(%+v) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	testCases := map[string]struct {
+		in   *policy
+		want bool
+	}{
+		"nil":             {nil, true},
+		"empty":           {&policy{}, true},
+		"valid":           {&policy{LockedShortcuts: []string{"Terminal"}, AllowedCommandPrefixes: []string{"/usr/bin/"}}, true},
+		"empty name":      {&policy{LockedShortcuts: []string{"Terminal", ""}}, false},
+		"empty prefix":    {&policy{AllowedCommandPrefixes: []string{""}}, false},
+		"relative prefix": {&policy{AllowedCommandPrefixes: []string{"bin/"}}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validatePolicy(&config{Policy: testCase.in})
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validatePolicy(%+v) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateRecent(t *testing.T) {
+	testCases := map[string]struct {
+		in   *recent
+		want bool
+	}{
+		"nil":                 {nil, true},
+		"empty":               {&recent{}, true},
+		"valid":               {&recent{Limit: 20, MimeTypes: []string{"text/plain", "image/*"}, Applications: []string{"gedit"}}, true},
+		"negative limit":      {&recent{Limit: -1}, false},
+		"too large limit":     {&recent{Limit: 21}, false},
+		"mime type no slash":  {&recent{MimeTypes: []string{"text"}}, false},
+		"mime type bad match": {&recent{MimeTypes: []string{"text/["}}, false},
+		"empty application":   {&recent{Applications: []string{""}}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateRecent(&config{Recent: testCase.in})
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateRecent(%+v) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateSort(t *testing.T) {
+	testCases := map[string]struct {
+		in   string
+		want bool
+	}{
+		"empty":        {"", true},
+		"config":       {"config", true},
+		"most used":    {"mostUsed", true},
+		"recent":       {"recent", true},
+		"alphabetical": {"alphabetical", true},
+		"lowercase":    {"mostused", false},
+		"unknown":      {"random", false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateSort(&config{Sort: testCase.in})
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateSort(%s) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateValidation(t *testing.T) {
+	testCases := map[string]struct {
+		in   string
+		want bool
+	}{
+		"empty":     {"", true},
+		"strict":    {"strict", true},
+		"lenient":   {"lenient", true},
+		"uppercase": {"Lenient", false},
+		"unknown":   {"loose", false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateValidation(&config{Validation: testCase.in})
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateValidation(%s) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateLenient(t *testing.T) {
+	in := newValidConfig()
+	in.Validation = "lenient"
+	in.Shortcuts[1].Icon = "not-exists.png"
+	in.Profiles = []*profile{{Name: "ops", Shortcuts: []*shortcut{
+		{Name: "Status", Command: "echo", Patch: "merge"},
+		{Name: "Terminal", Patch: "swap"},
+	}}}
+	want := newValidConfig()
+	want.Validation = "lenient"
+	want.Shortcuts = slices.Delete(want.Shortcuts, 1, 2)
+	want.Profiles = []*profile{{Name: "ops", Shortcuts: []*shortcut{{Name: "Status", Command: "echo", Patch: "merge"}}}}
+	want.Invalid = []*frontend.InvalidShortcut{
+		{Name: "Test2", Problem: "Icon of \"Test2\" Shortcut does not exist under: \"not-exists.png\""},
+		{Name: "Terminal", Profile: "ops", Problem: "Patch of \"Terminal\" Shortcut must be either \"replace\", \"merge\", \"delete\", \"move\" or \"rename\" (got \"swap\")"},
+	}
+
+	if err := validate(in); err != nil {
+		t.Fatalf("validate() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(want, in); diff != "" {
+		t.Errorf("validate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateAfterLaunch(t *testing.T) {
+	testCases := map[string]struct {
+		in   string
+		want bool
+	}{
+		"empty":    {"", true},
+		"keep":     {"keep", true},
+		"minimise": {"minimise", true},
+		"hide":     {"hide", true},
+		"quit":     {"quit", true},
+		"minimize": {"minimize", false},
+		"close":    {"close", false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateAfterLaunch(testCase.in)
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateAfterLaunch(%s) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateShortcutPath(t *testing.T) {
 	testCases := map[string]struct {
 		in   *shortcut
 		want bool
 	}{
-		"empty":           {&shortcut{Url: ""}, true}, // We can have other actions
-		"invalid":         {&shortcut{Url: "invalid"}, false},
-		"invalid www":     {&shortcut{Url: "www.example.com"}, false},
-		"invalid http":    {&shortcut{Url: "http:/example.com"}, false},
-		"valid http":      {&shortcut{Url: "http://example.com"}, true},
-		"valid http www":  {&shortcut{Url: "http://www.example.com"}, true},
-		"invalid https":   {&shortcut{Url: "https:/example.com"}, false},
-		"valid https":     {&shortcut{Url: "https://example.com"}, true},
-		"valid https www": {&shortcut{Url: "https://www.example.com"}, true},
+		"empty":      {&shortcut{Path: ""}, true}, // We can have other actions
+		"not exists": {&shortcut{Path: "not-exists"}, false},
+		"file":       {&shortcut{Path: "validator.go"}, true},
+		"dir":        {&shortcut{Path: t.TempDir()}, true},
 	}
 
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
-			err := validateShortcutUrl(testCase.in)
+			err := validateShortcutPath(testCase.in)
 			if got := err == nil; got != testCase.want {
-				t.Errorf("validateShortcutUrl(%q) = %t, want %t", testCase.in.Url, got, testCase.want)
+				t.Errorf("validateShortcutPath(%q) = %t, want %t", testCase.in.Path, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateBrowsers(t *testing.T) {
+	testCases := map[string]struct {
+		in   []*browser
+		want bool
+	}{
+		"empty":            {nil, true},
+		"nil":              {[]*browser{nil}, false},
+		"valid":            {[]*browser{{Name: "Work", Command: "echo", CommandArgs: &commandArgs{Line: "-P work --new-tab {url}"}}}, true},
+		"short name":       {[]*browser{{Name: "Wo", Command: "echo"}}, false},
+		"no command":       {[]*browser{{Name: "Work"}}, false},
+		"invalid command":  {[]*browser{{Name: "Work", Command: "echoo"}}, false},
+		"duplicated names": {[]*browser{{Name: "Work", Command: "echo"}, {Name: "Work", Command: "echo"}}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateBrowsers(&config{Browsers: testCase.in})
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateBrowsers() = %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateProfiles(t *testing.T) {
+	browsers := []*browser{{Name: "Work", Command: "echo"}}
+	testCases := map[string]struct {
+		in   []*profile
+		want bool
+	}{
+		"empty":            {nil, true},
+		"nil":              {[]*profile{nil}, false},
+		"valid":            {[]*profile{{Name: "ops", Title: "Operations", Shortcuts: []*shortcut{{Name: "Terminal", Patch: "delete"}}}}, true},
+		"config browser":   {[]*profile{{Name: "ops", Shortcuts: []*shortcut{{Name: "Status", Url: "https://example.com", Browser: "Work", Patch: "merge"}}}}, true},
+		"short name":       {[]*profile{{Name: "op"}}, false},
+		"short title":      {[]*profile{{Name: "ops", Title: "Op"}}, false},
+		"invalid shortcut": {[]*profile{{Name: "ops", Shortcuts: []*shortcut{{Name: "Terminal", Patch: "move"}}}}, false},
+		"duplicated names": {[]*profile{{Name: "ops"}, {Name: "ops"}}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateProfiles(&config{Browsers: browsers, Profiles: testCase.in})
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateProfiles() = %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateShortcutBrowser(t *testing.T) {
+	browsers := []*browser{{Name: "Work", Command: "echo"}}
+	testCases := map[string]struct {
+		in   *shortcut
+		want bool
+	}{
+		"empty":                     {&shortcut{Url: "https://example.com"}, true},
+		"defined":                   {&shortcut{Url: "https://example.com", Browser: "Work"}, true},
+		"undefined single word":     {&shortcut{Url: "https://example.com", Browser: "Personal"}, true}, // It may be defined in the other config
+		"valid template":            {&shortcut{Url: "https://example.com", Browser: "echo -P work --new-tab {url}"}, true},
+		"invalid template":          {&shortcut{Url: "https://example.com", Browser: "echoo -P work --new-tab {url}"}, false},
+		"unbalanced quote":          {&shortcut{Url: "https://example.com", Browser: "echo -P 'work {url}"}, false},
+		"without url":               {&shortcut{Command: "echo", Browser: "Work"}, false},
+		"without url in patch mode": {&shortcut{Browser: "Work", Patch: patchMerge}, true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateShortcutBrowser(testCase.in, browsers)
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateShortcutBrowser(%q) = %t, want %t", testCase.in.Browser, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateShortcutActions(t *testing.T) {
+	testCases := map[string]struct {
+		in   []*action
+		want bool
+	}{
+		"empty":                     {nil, true},
+		"nil action":                {[]*action{nil}, false},
+		"command":                   {[]*action{{Command: "echo", CommandArgs: &commandArgs{Line: "test"}}}, true},
+		"url":                       {[]*action{{Url: "https://example.com"}}, true},
+		"path":                      {[]*action{{Path: "validator.go"}}, true},
+		"invalid path":              {[]*action{{Path: "not-exists"}}, false},
+		"not allowed url scheme":    {[]*action{{Url: "ssh://example.com"}}, false},
+		"both empty":                {[]*action{{}}, false},
+		"both not empty":            {[]*action{{Command: "echo", Url: "https://example.com"}}, false},
+		"invalid command":           {[]*action{{Command: "invalid"}}, false},
+		"command args only":         {[]*action{{CommandArgs: &commandArgs{Line: "test"}}}, false},
+		"invalid url":               {[]*action{{Url: "www.example.com"}}, false},
+		"parallel":                  {[]*action{{Command: "echo"}, {Command: "echo", Parallel: true}}, true},
+		"first parallel":            {[]*action{{Command: "echo", Parallel: true}}, false},
+		"wait for exit":             {[]*action{{Command: "echo"}, {Command: "echo", WaitFor: &waitFor{Exit: true}}}, true},
+		"first wait for exit":       {[]*action{{Command: "echo", WaitFor: &waitFor{Exit: true}}}, false},
+		"parallel wait for exit":    {[]*action{{Command: "echo"}, {Command: "echo", WaitFor: &waitFor{Exit: true}, Parallel: true}}, false},
+		"wait for delay":            {[]*action{{Command: "echo", WaitFor: &waitFor{Delay: "1m30s"}}}, true},
+		"invalid wait for delay":    {[]*action{{Command: "echo", WaitFor: &waitFor{Delay: "2 seconds"}}}, false},
+		"negative wait for delay":   {[]*action{{Command: "echo", WaitFor: &waitFor{Delay: "-2s"}}}, false},
+		"wait for port":             {[]*action{{Command: "echo", WaitFor: &waitFor{Port: "localhost:8080"}}}, true},
+		"invalid wait for port":     {[]*action{{Command: "echo", WaitFor: &waitFor{Port: "localhost"}}}, false},
+		"second action invalid url": {[]*action{{Command: "echo"}, {Url: "example.com"}}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateShortcutActions(&shortcut{Name: "Test", Actions: testCase.in}, (&config{}).allowedUrlSchemes())
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateShortcutActions() = %v, want %t", err, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateShortcutMenu(t *testing.T) {
+	testCases := map[string]struct {
+		in   []*menuItem
+		want bool
+	}{
+		"empty":               {nil, true},
+		"nil menu item":       {[]*menuItem{nil}, false},
+		"command":             {[]*menuItem{{Label: "Label", Command: "echo", CommandArgs: &commandArgs{Line: "test"}}}, true},
+		"url":                 {[]*menuItem{{Label: "Label", Url: "https://example.com"}}, true},
+		"path":                {[]*menuItem{{Label: "Label", Path: "validator.go"}}, true},
+		"url and path":        {[]*menuItem{{Label: "Label", Url: "https://example.com", Path: "validator.go"}}, false},
+		"no label":            {[]*menuItem{{Command: "echo"}}, false},
+		"short label":         {[]*menuItem{{Label: "La", Command: "echo"}}, false},
+		"long label":          {[]*menuItem{{Label: "Test Title Test Test Title Test", Command: "echo"}}, false},
+		"both empty":          {[]*menuItem{{Label: "Label"}}, false},
+		"both not empty":      {[]*menuItem{{Label: "Label", Command: "echo", Url: "https://example.com"}}, false},
+		"invalid command":     {[]*menuItem{{Label: "Label", Command: "invalid"}}, false},
+		"command args only":   {[]*menuItem{{Label: "Label", CommandArgs: &commandArgs{Line: "test"}}}, false},
+		"invalid url":         {[]*menuItem{{Label: "Label", Url: "www.example.com"}}, false},
+		"second item invalid": {[]*menuItem{{Label: "Label", Command: "echo"}, {Label: "Label"}}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateShortcutMenu(&shortcut{Name: "Test", Menu: testCase.in}, (&config{}).allowedUrlSchemes())
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateShortcutMenu() = %v, want %t", err, testCase.want)
 			}
 		})
 	}
@@ -0,0 +1,144 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/pkg/errors"
+)
+
+// when limits a shortcut to the machines meeting all its conditions, so one config can serve different machines.
+type when struct {
+	Host          string
+	OS            string `yaml:"os"`
+	Env           []string
+	CommandExists string `yaml:"commandExists"`
+	FileExists    string `yaml:"fileExists"`
+}
+
+var supportedOS = []string{"linux", "windows", "darwin"}
+
+var getHostname = os.Hostname
+
+// Trims all strings in the when clause.
+func (w *when) trim() {
+	if w == nil {
+		return
+	}
+	w.Host = strings.TrimSpace(w.Host)
+	w.OS = strings.ToLower(strings.TrimSpace(w.OS))
+	for i, env := range w.Env {
+		w.Env[i] = strings.TrimSpace(env)
+	}
+	w.CommandExists = strings.TrimSpace(w.CommandExists)
+	w.FileExists = strings.TrimSpace(w.FileExists)
+}
+
+// dropUnmetShortcuts drops the shortcuts, also of the profiles, whose when clause is not met on this machine. They are
+// recorded as skipped, as they are not validated and would otherwise silently go missing.
+func (yc *config) dropUnmetShortcuts() error {
+	if yc == nil {
+		return nil
+	}
+	shortcuts, err := yc.dropUnmet(yc.Shortcuts, "")
+	if err != nil {
+		return err
+	}
+	yc.Shortcuts = shortcuts
+	for _, profile := range yc.Profiles {
+		if profile == nil {
+			continue
+		}
+		profileShortcuts, err := yc.dropUnmet(profile.Shortcuts, profile.Name)
+		if err != nil {
+			return errors.WithMessagef(err, "\"%s\" Profile is invalid", profile.Name)
+		}
+		profile.Shortcuts = profileShortcuts
+	}
+	return nil
+}
+
+func (yc *config) dropUnmet(shortcuts []*shortcut, profile string) ([]*shortcut, error) {
+	var metShortcuts []*shortcut
+	for _, shortcut := range shortcuts {
+		if shortcut == nil || shortcut.When == nil {
+			metShortcuts = append(metShortcuts, shortcut)
+			continue
+		}
+		if err := validateWhen(shortcut.When); err != nil {
+			return nil, errors.WithMessagef(err, "When of \"%s\" Shortcut is invalid", shortcut.Name)
+		}
+		if reason := shortcut.When.unmetCondition(); reason != "" {
+			lctx.LogInfof("Skipping \"%s\" Shortcut as %s", shortcut.Name, reason)
+			yc.Skipped = append(yc.Skipped, &frontend.SkippedShortcut{Name: shortcut.Name, Profile: profile, Reason: reason})
+			continue
+		}
+		metShortcuts = append(metShortcuts, shortcut)
+	}
+	return metShortcuts, nil
+}
+
+// unmetCondition describes the first condition not met on this machine, or returns "" when all of them are met.
+func (w *when) unmetCondition() string {
+	if w.Host != "" {
+		hostname, _ := getHostname()
+		if matched, _ := path.Match(strings.ToLower(w.Host), strings.ToLower(hostname)); !matched {
+			return fmt.Sprintf("host \"%s\" does not match \"%s\"", hostname, w.Host)
+		}
+	}
+	if w.OS != "" && w.OS != getGOOS() {
+		return fmt.Sprintf("OS \"%s\" is not \"%s\"", getGOOS(), w.OS)
+	}
+	for _, env := range w.Env {
+		name, wantValue, hasValue := strings.Cut(env, "=")
+		value, set := os.LookupEnv(name)
+		if !set {
+			return fmt.Sprintf("environment variable \"%s\" is not set", name)
+		} else if hasValue && value != wantValue {
+			return fmt.Sprintf("environment variable \"%s\" is not \"%s\"", name, wantValue)
+		}
+	}
+	if w.CommandExists != "" && !isExec(w.CommandExists) {
+		return fmt.Sprintf("command \"%s\" does not exist", w.CommandExists)
+	}
+	if w.FileExists != "" && !fileExists(w.FileExists) {
+		return fmt.Sprintf("file \"%s\" does not exist", w.FileExists)
+	}
+	return ""
+}
+
+func validateWhen(w *when) error {
+	if _, err := path.Match(w.Host, ""); err != nil {
+		return errors.Errorf("Host must be a valid pattern, e.g. \"laptop-*\" (got \"%s\")", w.Host)
+	}
+	if w.OS != "" && !slices.Contains(supportedOS, w.OS) {
+		return errors.Errorf("OS must be \"%s\" (got \"%s\")", strings.Join(supportedOS, "\", \""), w.OS)
+	}
+	for _, env := range w.Env {
+		if name, _, _ := strings.Cut(env, "="); name == "" {
+			return errors.Errorf("Env must be a variable name, optionally with a value, e.g. \"DISPLAY\" or \"XDG_SESSION_TYPE=wayland\" (got \"%s\")", env)
+		}
+	}
+	return nil
+}
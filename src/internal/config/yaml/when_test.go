@@ -0,0 +1,156 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/lctx"
+	"github.com/jdheim/launchee/internal/test/stub"
+)
+
+func TestWhenTrim(t *testing.T) {
+	in := &when{Host: "  laptop-*  ", OS: "  Linux  ", Env: []string{"  DISPLAY  "}, CommandExists: "  echo  ", FileExists: "  /tmp  "}
+	want := &when{Host: "laptop-*", OS: "linux", Env: []string{"DISPLAY"}, CommandExists: "echo", FileExists: "/tmp"}
+	in.trim()
+	if diff := cmp.Diff(want, in); diff != "" {
+		t.Errorf("trim() = diff -want +got\n%s", diff)
+	}
+	(*when)(nil).trim()
+}
+
+func TestWhenUnmetCondition(t *testing.T) {
+	t.Setenv("LAUNCHEE_TEST_SESSION", "wayland")
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	testCases := map[string]struct {
+		in   *when
+		want string
+	}{
+		"empty":              {&when{}, ""},
+		"host":               {&when{Host: "Laptop-*"}, ""},
+		"other host":         {&when{Host: "server-*"}, "host \"laptop-01\" does not match \"server-*\""},
+		"os":                 {&when{OS: "linux"}, ""},
+		"other os":           {&when{OS: "windows"}, "OS \"linux\" is not \"windows\""},
+		"env set":            {&when{Env: []string{"LAUNCHEE_TEST_SESSION"}}, ""},
+		"env equal":          {&when{Env: []string{"LAUNCHEE_TEST_SESSION=wayland"}}, ""},
+		"env not set":        {&when{Env: []string{"LAUNCHEE_TEST_UNSET"}}, "environment variable \"LAUNCHEE_TEST_UNSET\" is not set"},
+		"env not equal":      {&when{Env: []string{"LAUNCHEE_TEST_SESSION=x11"}}, "environment variable \"LAUNCHEE_TEST_SESSION\" is not \"x11\""},
+		"command exists":     {&when{CommandExists: "echo"}, ""},
+		"command not exists": {&when{CommandExists: "echoo"}, "command \"echoo\" does not exist"},
+		"file exists":        {&when{FileExists: file}, ""},
+		"file not exists":    {&when{FileExists: file + ".missing"}, "file \"" + file + ".missing\" does not exist"},
+		"all":                {&when{Host: "laptop-01", OS: "linux", Env: []string{"LAUNCHEE_TEST_SESSION"}, CommandExists: "echo", FileExists: file}, ""},
+		"first unmet":        {&when{OS: "darwin", CommandExists: "echoo"}, "OS \"linux\" is not \"darwin\""},
+	}
+
+	originalGetHostname, originalGetGOOS := getHostname, getGOOS
+	defer func() { getHostname, getGOOS = originalGetHostname, originalGetGOOS }()
+	getHostname = func() (string, error) { return "laptop-01", nil }
+	getGOOS = func() string { return "linux" }
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := testCase.in.unmetCondition(); got != testCase.want {
+				t.Errorf("unmetCondition() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestValidateWhen(t *testing.T) {
+	testCases := map[string]struct {
+		in   *when
+		want bool
+	}{
+		"empty":           {&when{}, true},
+		"valid":           {&when{Host: "laptop-*", OS: "darwin", Env: []string{"DISPLAY", "XDG_SESSION_TYPE=wayland"}}, true},
+		"invalid host":    {&when{Host: "laptop-["}, false},
+		"unsupported os":  {&when{OS: "plan9"}, false},
+		"empty env name":  {&when{Env: []string{"=wayland"}}, false},
+		"empty env entry": {&when{Env: []string{""}}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateWhen(testCase.in)
+			if got := err == nil; got != testCase.want {
+				t.Errorf("validateWhen(%+v) = %t, want %t", testCase.in, got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestDropUnmetShortcuts(t *testing.T) {
+	lctx.LoggerImpl = stub.LoggerStub{}
+	testCases := map[string]struct {
+		in      *config
+		want    *config
+		wantErr bool
+	}{
+		"nil": {nil, nil, false},
+		"met": {
+			&config{Shortcuts: []*shortcut{{Name: "Terminal", When: &when{CommandExists: "echo"}}, {Name: "Firefox"}}},
+			&config{Shortcuts: []*shortcut{{Name: "Terminal", When: &when{CommandExists: "echo"}}, {Name: "Firefox"}}},
+			false,
+		},
+		"unmet": {
+			&config{
+				Shortcuts: []*shortcut{{Name: "Steam", When: &when{CommandExists: "echoo"}}, {Name: "Firefox"}},
+				Profiles: []*profile{nil, {Name: "ops", Shortcuts: []*shortcut{
+					{Name: "Status", When: &when{Env: []string{"LAUNCHEE_TEST_UNSET"}}},
+				}}},
+			},
+			&config{
+				Shortcuts: []*shortcut{{Name: "Firefox"}},
+				Profiles:  []*profile{nil, {Name: "ops"}},
+				Skipped: []*frontend.SkippedShortcut{
+					{Name: "Steam", Reason: "command \"echoo\" does not exist"},
+					{Name: "Status", Profile: "ops", Reason: "environment variable \"LAUNCHEE_TEST_UNSET\" is not set"},
+				},
+			},
+			false,
+		},
+		"invalid": {
+			&config{Shortcuts: []*shortcut{{Name: "Steam", When: &when{OS: "plan9"}}}},
+			&config{Shortcuts: []*shortcut{{Name: "Steam", When: &when{OS: "plan9"}}}},
+			true,
+		},
+		"invalid profile": {
+			&config{Profiles: []*profile{{Name: "ops", Shortcuts: []*shortcut{{Name: "Steam", When: &when{OS: "plan9"}}}}}},
+			&config{Profiles: []*profile{{Name: "ops", Shortcuts: []*shortcut{{Name: "Steam", When: &when{OS: "plan9"}}}}}},
+			true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.in.dropUnmetShortcuts()
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("dropUnmetShortcuts() = %v, want error %t", err, testCase.wantErr)
+			}
+			if diff := cmp.Diff(testCase.want, testCase.in); diff != "" {
+				t.Errorf("dropUnmetShortcuts() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package control serves the local control API, which lets scripts and editor plugins drive the running dock.
+// Requests and responses are JSON documents, one per line, sent over a Unix domain socket. They are described by
+// schema.json.
+package control
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/jdheim/launchee/internal/socket"
+	"github.com/pkg/errors"
+)
+
+const (
+	MethodListShortcuts = "listShortcuts"
+	MethodLaunch        = "launch"
+	MethodListProcesses = "listProcesses"
+	MethodReload        = "reload"
+	MethodShow          = "show"
+	MethodHide          = "hide"
+)
+
+const socketName = "launchee-control"
+
+// Schema is the JSON Schema of the requests and responses.
+//
+//go:embed schema.json
+var Schema []byte
+
+type Request struct {
+	Id     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+type Response struct {
+	Id     json.RawMessage `json:"id,omitempty"`
+	Result any             `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type Shortcut struct {
+	Id          int      `json:"id"`
+	StableId    string   `json:"stableId"`
+	Name        string   `json:"name"`
+	Command     string   `json:"command,omitempty"`
+	CommandArgs []string `json:"commandArgs,omitempty"`
+	Url         string   `json:"url,omitempty"`
+	Path        string   `json:"path,omitempty"`
+}
+
+type Process struct {
+	Shortcut string `json:"shortcut"`
+	Pid      int    `json:"pid"`
+}
+
+// LaunchParams selects the shortcut by its name or stable id or, when the name is empty, by its id.
+type LaunchParams struct {
+	Name string `json:"name,omitempty"`
+	Id   *int   `json:"id,omitempty"`
+}
+
+// Controller is the dock driven by the API.
+type Controller interface {
+	ListShortcuts() []*Shortcut
+	Launch(params *LaunchParams) error
+	ListProcesses() []*Process
+	Reload() error
+	SetWindowHidden(hidden bool)
+}
+
+type Server struct {
+	server     *socket.Server
+	controller Controller
+}
+
+// SocketPath returns the path of the socket under $XDG_RUNTIME_DIR or, when it is not set, under the temp dir.
+func SocketPath() string {
+	return socket.Path(socketName)
+}
+
+// Listen starts serving the API on the socket, which only the current user can access.
+func Listen(path string, controller Controller) (*Server, error) {
+	server := &Server{controller: controller}
+	var err error
+	if server.server, err = socket.Listen(path, server.handle); errors.Is(err, socket.ErrInUse) {
+		return nil, errors.Errorf("Control API socket %s is already in use", path)
+	} else if err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// Close stops the API, closes the open connections and removes the socket.
+func (s *Server) Close() error {
+	return s.server.Close()
+}
+
+// handle answers the requests sent over the connection until it is closed.
+func (s *Server) handle(conn net.Conn) {
+	decoder := json.NewDecoder(conn)
+	encoder := json.NewEncoder(conn)
+	for {
+		var request Request
+		if err := decoder.Decode(&request); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				_ = encoder.Encode(&Response{Error: fmt.Sprintf("Invalid request: %v", err)})
+			}
+			return
+		}
+		if err := encoder.Encode(s.dispatch(&request)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) dispatch(request *Request) *Response {
+	response := &Response{Id: request.Id}
+	var err error
+	switch request.Method {
+	case MethodListShortcuts:
+		response.Result = s.controller.ListShortcuts()
+	case MethodLaunch:
+		var params LaunchParams
+		if len(request.Params) != 0 {
+			err = json.Unmarshal(request.Params, &params)
+		}
+		if err == nil {
+			err = validateLaunchParams(&params)
+		}
+		if err == nil {
+			err = s.controller.Launch(&params)
+		}
+	case MethodListProcesses:
+		response.Result = s.controller.ListProcesses()
+	case MethodReload:
+		err = s.controller.Reload()
+	case MethodShow:
+		s.controller.SetWindowHidden(false)
+	case MethodHide:
+		s.controller.SetWindowHidden(true)
+	default:
+		err = errors.Errorf("Unknown method \"%s\"", request.Method)
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	return response
+}
+
+func validateLaunchParams(params *LaunchParams) error {
+	if (params.Name == "") == (params.Id == nil) {
+		return errors.New("Either name or id of the shortcut is required")
+	}
+	return nil
+}
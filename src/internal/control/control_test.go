@@ -0,0 +1,208 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/internal/test/tempdir"
+	"github.com/pkg/errors"
+)
+
+type testController struct {
+	hidden   bool
+	launched *LaunchParams
+}
+
+func (c *testController) ListShortcuts() []*Shortcut {
+	return []*Shortcut{
+		{Id: 0, StableId: "term", Name: "Terminal", Command: "kitty"},
+		{Id: 1, StableId: "Docs", Name: "Docs", Url: "https://example.com"},
+	}
+}
+
+func (c *testController) Launch(params *LaunchParams) error {
+	if params.Name == "Unknown" {
+		return errors.New("Shortcut \"Unknown\" not found")
+	}
+	c.launched = params
+	return nil
+}
+
+func (c *testController) ListProcesses() []*Process {
+	return []*Process{{Shortcut: "term", Pid: 42}}
+}
+
+func (c *testController) Reload() error {
+	return nil
+}
+
+func (c *testController) SetWindowHidden(hidden bool) {
+	c.hidden = hidden
+}
+
+func testSocketPath(t *testing.T) string {
+	t.Helper()
+	return filepath.Join(tempdir.Short(t), socketName+".sock")
+}
+
+func TestSocketPath(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	if got, want := SocketPath(), "/run/user/1000/launchee-control.sock"; got != want {
+		t.Errorf("SocketPath() = %q, want %q", got, want)
+	}
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if got := SocketPath(); filepath.Dir(filepath.Dir(got)) != filepath.Clean(os.TempDir()) {
+		t.Errorf("SocketPath() = %q, want it in %q", got, os.TempDir())
+	}
+}
+
+func TestServer(t *testing.T) {
+	path := testSocketPath(t)
+	controller := &testController{}
+	server, err := Listen(path, controller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = server.Close() }()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	reader := bufio.NewReader(conn)
+
+	testCases := []struct {
+		name    string
+		request string
+		want    string
+	}{
+		{"list shortcuts", `{"id":1,"method":"listShortcuts"}`,
+			`{"id":1,"result":[{"id":0,"stableId":"term","name":"Terminal","command":"kitty"},{"id":1,"stableId":"Docs","name":"Docs","url":"https://example.com"}]}`},
+		{"launch by name", `{"id":"a","method":"launch","params":{"name":"Terminal"}}`, `{"id":"a"}`},
+		{"launch by id", `{"method":"launch","params":{"id":0}}`, `{}`},
+		{"launch unknown", `{"method":"launch","params":{"name":"Unknown"}}`, `{"error":"Shortcut \"Unknown\" not found"}`},
+		{"launch without params", `{"method":"launch"}`, `{"error":"Either name or id of the shortcut is required"}`},
+		{"launch invalid params", `{"method":"launch","params":{"id":"0"}}`, `{"error":"json: cannot unmarshal string into Go struct field LaunchParams.id of type int"}`},
+		{"launch without shortcut", `{"method":"launch","params":{}}`, `{"error":"Either name or id of the shortcut is required"}`},
+		{"list processes", `{"method":"listProcesses"}`, `{"result":[{"shortcut":"term","pid":42}]}`},
+		{"reload", `{"method":"reload"}`, `{}`},
+		{"hide", `{"method":"hide"}`, `{}`},
+		{"show", `{"method":"show"}`, `{}`},
+		{"unknown method", `{"method":"unknown"}`, `{"error":"Unknown method \"unknown\""}`},
+	}
+
+	// The requests are sent one after another over the same connection
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := conn.Write([]byte(testCase.request + "\n")); err != nil {
+				t.Fatal(err)
+			}
+			got, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(testCase.want+"\n", got); diff != "" {
+				t.Errorf("response = diff -want +got\n%s", diff)
+			}
+			if testCase.name == "hide" && !controller.hidden || testCase.name == "show" && controller.hidden {
+				t.Errorf("SetWindowHidden() hidden = %t", controller.hidden)
+			}
+		})
+	}
+	if controller.launched == nil || controller.launched.Id == nil || *controller.launched.Id != 0 {
+		t.Errorf("Launch() = %+v, want id 0", controller.launched)
+	}
+
+	if _, err = conn.Write([]byte("invalid\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := reader.ReadString('\n'); got == "" {
+		t.Error("response = an error expected for an invalid request")
+	}
+}
+
+func TestListen(t *testing.T) {
+	path := testSocketPath(t)
+	server, err := Listen(path, &testController{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Listen(path, &testController{}); err == nil {
+		t.Error("Listen() = error expected for a socket in use")
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	if err = server.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+	if _, err = os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Close() left the socket behind: %v", err)
+	}
+
+	if err = os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if server, err = Listen(path, &testController{}); err != nil {
+		t.Errorf("Listen() = %v, want a stale socket to be replaced", err)
+	} else {
+		_ = server.Close()
+	}
+
+	if _, err = Listen(filepath.Join(path, "not-exists", socketName+".sock"), &testController{}); err == nil {
+		t.Error("Listen() = error expected")
+	}
+}
+
+func TestSchema(t *testing.T) {
+	var schema struct {
+		Defs struct {
+			Request struct {
+				Properties struct {
+					Method struct {
+						Enum []string `json:"enum"`
+					} `json:"method"`
+				} `json:"properties"`
+			} `json:"request"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(Schema, &schema); err != nil {
+		t.Fatalf("Schema is not valid JSON: %v", err)
+	}
+	want := []string{MethodListShortcuts, MethodLaunch, MethodListProcesses, MethodReload, MethodShow, MethodHide}
+	got := schema.Defs.Request.Properties.Method.Enum
+	for _, method := range want {
+		if !slices.Contains(got, method) {
+			t.Errorf("Schema is missing method %q", method)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("Schema methods = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dbusapi exposes the dock as a D-Bus service on the session bus, so it can be scripted like the rest of
+// a Linux desktop.
+package dbusapi
+
+import (
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/pkg/errors"
+)
+
+const (
+	ServiceName            = "com.jdheim.Launchee"
+	ObjectPath             = dbus.ObjectPath("/com/jdheim/Launchee")
+	InterfaceName          = "com.jdheim.Launchee"
+	SignalShortcutLaunched = InterfaceName + ".ShortcutLaunched"
+)
+
+// Controller is the dock driven by the service.
+type Controller interface {
+	SetWindowHidden(hidden bool)
+	ToggleWindow()
+	Launch(name string) error
+	Reload() error
+}
+
+type Service struct {
+	conn *dbus.Conn
+}
+
+// object holds the methods exported on the bus.
+type object struct {
+	controller Controller
+}
+
+func (o object) Show() *dbus.Error {
+	o.controller.SetWindowHidden(false)
+	return nil
+}
+
+func (o object) Hide() *dbus.Error {
+	o.controller.SetWindowHidden(true)
+	return nil
+}
+
+func (o object) Toggle() *dbus.Error {
+	o.controller.ToggleWindow()
+	return nil
+}
+
+func (o object) Launch(name string) *dbus.Error {
+	if err := o.controller.Launch(name); err != nil {
+		return dbus.MakeFailedError(err)
+	}
+	return nil
+}
+
+func (o object) Reload() *dbus.Error {
+	if err := o.controller.Reload(); err != nil {
+		return dbus.MakeFailedError(err)
+	}
+	return nil
+}
+
+// Connect exports the service on the session bus.
+func Connect(controller Controller) (*Service, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not connect to the session bus")
+	}
+	if err = export(conn, object{controller: controller}); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return &Service{conn: conn}, nil
+}
+
+func export(conn *dbus.Conn, o object) error {
+	if err := conn.Export(o, ObjectPath, InterfaceName); err != nil {
+		return err
+	}
+	node := &introspect.Node{
+		Name: string(ObjectPath),
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			{
+				Name:    InterfaceName,
+				Methods: introspect.Methods(o),
+				Signals: []introspect.Signal{{
+					Name: "ShortcutLaunched",
+					Args: []introspect.Arg{{Name: "name", Type: "s"}},
+				}},
+			},
+		},
+	}
+	if err := conn.Export(introspect.NewIntrospectable(node), ObjectPath, "org.freedesktop.DBus.Introspectable"); err != nil {
+		return err
+	}
+	reply, err := conn.RequestName(ServiceName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		return err
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		return errors.Errorf("D-Bus name %s is already taken", ServiceName)
+	}
+	return nil
+}
+
+// EmitShortcutLaunched tells the listeners that the shortcut has been launched.
+func (s *Service) EmitShortcutLaunched(name string) error {
+	return s.conn.Emit(ObjectPath, SignalShortcutLaunched, name)
+}
+
+// Close releases the name and disconnects from the bus.
+func (s *Service) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,164 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dbusapi
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/jdheim/launchee/internal/test/bus"
+	"github.com/pkg/errors"
+)
+
+type controllerStub struct {
+	lock  sync.Mutex
+	calls []string
+}
+
+// record records the call, which is made by the goroutines of the bus connection.
+func (c *controllerStub) record(call string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calls = append(c.calls, call)
+}
+
+func (c *controllerStub) recorded() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return slices.Clone(c.calls)
+}
+
+func (c *controllerStub) SetWindowHidden(hidden bool) {
+	if hidden {
+		c.record("hide")
+	} else {
+		c.record("show")
+	}
+}
+
+func (c *controllerStub) ToggleWindow() {
+	c.record("toggle")
+}
+
+func (c *controllerStub) Launch(name string) error {
+	if name == "Unknown" {
+		return errors.New("Shortcut \"Unknown\" not found")
+	}
+	c.record("launch " + name)
+	return nil
+}
+
+func (c *controllerStub) Reload() error {
+	c.record("reload")
+	return nil
+}
+
+func TestService(t *testing.T) {
+	bus.StartSessionBus(t)
+	controller := &controllerStub{}
+	service, err := Connect(controller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = service.Close() }()
+
+	client, err := dbus.ConnectSessionBus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Close() }()
+	object := client.Object(ServiceName, ObjectPath)
+
+	testCases := map[string]struct {
+		method  string
+		args    []any
+		wantErr bool
+	}{
+		"show":           {"Show", nil, false},
+		"hide":           {"Hide", nil, false},
+		"toggle":         {"Toggle", nil, false},
+		"launch":         {"Launch", []any{"Terminal"}, false},
+		"launch unknown": {"Launch", []any{"Unknown"}, true},
+		"reload":         {"Reload", nil, false},
+		"unknown method": {"Unknown", nil, true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			call := object.Call(InterfaceName+"."+testCase.method, 0, testCase.args...)
+			if gotErr := call.Err != nil; gotErr != testCase.wantErr {
+				t.Errorf("Call(%s) = %v, want error %t", testCase.method, call.Err, testCase.wantErr)
+			}
+		})
+	}
+	if calls := controller.recorded(); len(calls) != 5 {
+		t.Errorf("calls = %v, want 5 calls", calls)
+	}
+}
+
+func TestEmitShortcutLaunched(t *testing.T) {
+	bus.StartSessionBus(t)
+	service, err := Connect(&controllerStub{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = service.Close() }()
+
+	client, err := dbus.ConnectSessionBus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Close() }()
+	if err = client.AddMatchSignal(dbus.WithMatchInterface(InterfaceName)); err != nil {
+		t.Fatal(err)
+	}
+	signals := make(chan *dbus.Signal, 1)
+	client.Signal(signals)
+
+	if err = service.EmitShortcutLaunched("Terminal"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case signal := <-signals:
+		if signal.Name != SignalShortcutLaunched || len(signal.Body) != 1 || signal.Body[0] != "Terminal" {
+			t.Errorf("signal = %s %v, want %s [Terminal]", signal.Name, signal.Body, SignalShortcutLaunched)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("ShortcutLaunched signal was not received")
+	}
+}
+
+func TestConnectNameTaken(t *testing.T) {
+	bus.StartSessionBus(t)
+	service, err := Connect(&controllerStub{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = service.Close() }()
+	if _, err = Connect(&controllerStub{}); err == nil {
+		t.Error("Connect() = error expected")
+	}
+}
+
+func TestConnectNoBus(t *testing.T) {
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", "unix:path=/nonexistent/launchee-bus")
+	if _, err := Connect(&controllerStub{}); err == nil {
+		t.Error("Connect() = error expected")
+	}
+}
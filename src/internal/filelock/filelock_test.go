@@ -0,0 +1,77 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	unlock, err := Lock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(path); err != nil {
+		t.Errorf("Lock() did not create %q: %v", path, err)
+	}
+	unlock()
+	unlock, err = Lock(path)
+	if err != nil {
+		t.Fatalf("Lock() after unlock = %v", err)
+	}
+	unlock()
+}
+
+func TestLockContention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	unlock, err := Lock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	locked := make(chan error, 1)
+	go func() {
+		secondUnlock, err := Lock(path)
+		if err == nil {
+			secondUnlock()
+		}
+		locked <- err
+	}()
+	select {
+	case err = <-locked:
+		t.Fatalf("Lock() = %v while the file is locked, want it to wait", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case err = <-locked:
+		if err != nil {
+			t.Errorf("Lock() after unlock = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Lock() still waits after unlock")
+	}
+}
+
+func TestLockMissingDir(t *testing.T) {
+	if _, err := Lock(filepath.Join(t.TempDir(), "missing", "test.lock")); err == nil {
+		t.Error("Lock() = nil, want error for the missing dir")
+	}
+}
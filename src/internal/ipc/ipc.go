@@ -0,0 +1,156 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ipc keeps Launchee single-instance: the running dock listens on a Unix domain socket and every other
+// invocation forwards its command there instead of starting a new dock.
+package ipc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/jdheim/launchee/internal/socket"
+	"github.com/pkg/errors"
+)
+
+const (
+	CommandShow   = "show"
+	CommandHide   = "hide"
+	CommandToggle = "toggle"
+	CommandRun    = "run"
+	CommandReload = "reload"
+)
+
+const socketName = "launchee"
+
+const timeout = 5 * time.Second
+
+var ErrAlreadyRunning = errors.New("Launchee is already running")
+
+var ErrNotRunning = errors.New("Launchee is not running")
+
+// Request is sent by a client as a single JSON document. Id is only used by the run command when Name is empty.
+// Profile, when set, switches the shown dock to it.
+type Request struct {
+	Command string `json:"command"`
+	Name    string `json:"name,omitempty"`
+	Id      int    `json:"id"`
+	Profile string `json:"profile,omitempty"`
+}
+
+type response struct {
+	Error  string `json:"error,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// Statuses of the failed requests, which tell the client the kind of the error.
+const (
+	StatusUnknownShortcut = "unknownShortcut"
+	StatusLaunchFailed    = "launchFailed"
+)
+
+// Error is the error the instance responded with, whose status, if any, tells its kind.
+type Error struct {
+	Status  string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// WithStatus returns the error for the handler to respond with along with the status.
+func WithStatus(status string, err error) error {
+	return &Error{Status: status, Message: err.Error()}
+}
+
+// Handler handles a request. The error is sent back to the client.
+type Handler func(request *Request) error
+
+type Server struct {
+	server  *socket.Server
+	handler Handler
+}
+
+// SocketPath returns the path of the socket under $XDG_RUNTIME_DIR or, when it is not set, under the temp dir.
+func SocketPath() string {
+	return socket.Path(socketName)
+}
+
+// Listen starts serving the requests on the socket. It returns ErrAlreadyRunning when another instance is listening
+// on it and removes the socket left behind by an instance that has not exited cleanly.
+func Listen(path string, handler Handler) (*Server, error) {
+	server := &Server{handler: handler}
+	var err error
+	server.server, err = socket.Listen(path, server.handle)
+	if errors.Is(err, socket.ErrInUse) {
+		return nil, ErrAlreadyRunning
+	} else if err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// Close stops accepting the requests, closes the open connections, waits for the requests being handled and removes
+// the socket.
+func (s *Server) Close() error {
+	return s.server.Close()
+}
+
+func (s *Server) handle(conn net.Conn) {
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+	var request Request
+	var resp response
+	if err := json.NewDecoder(conn).Decode(&request); err != nil {
+		resp.Error = fmt.Sprintf("Invalid request: %v", err)
+	} else if err = s.handler(&request); err != nil {
+		resp.Error = err.Error()
+		var statusErr *Error
+		if errors.As(err, &statusErr) {
+			resp.Status = statusErr.Status
+		}
+	}
+	_ = json.NewEncoder(conn).Encode(&resp)
+}
+
+// Running tells whether an instance is listening on the socket.
+func Running(path string) bool {
+	return socket.Listening(path)
+}
+
+// Send sends the request to the instance listening on the socket and returns the *Error it responded with.
+// It returns ErrNotRunning when no instance is listening.
+func Send(path string, request *Request) error {
+	conn, err := socket.Dial(path, timeout)
+	if err != nil {
+		return ErrNotRunning
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+	if err = json.NewEncoder(conn).Encode(request); err != nil {
+		return err
+	}
+	var resp response
+	if err = json.NewDecoder(conn).Decode(&resp); err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return &Error{Status: resp.Status, Message: resp.Error}
+	}
+	return nil
+}
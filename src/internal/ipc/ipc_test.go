@@ -0,0 +1,152 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ipc
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/internal/test/tempdir"
+	"github.com/pkg/errors"
+)
+
+func testSocketPath(t *testing.T) string {
+	t.Helper()
+	return filepath.Join(tempdir.Short(t), socketName+".sock")
+}
+
+func TestSocketPath(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	if got, want := SocketPath(), "/run/user/1000/launchee.sock"; got != want {
+		t.Errorf("SocketPath() = %q, want %q", got, want)
+	}
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if got := SocketPath(); filepath.Dir(filepath.Dir(got)) != filepath.Clean(os.TempDir()) {
+		t.Errorf("SocketPath() = %q, want it in %q", got, os.TempDir())
+	}
+}
+
+func TestSend(t *testing.T) {
+	path := testSocketPath(t)
+	var got []*Request
+	server, err := Listen(path, func(request *Request) error {
+		got = append(got, request)
+		if request.Command == CommandRun && request.Name == "" && request.Id < 0 {
+			return errors.New("Shortcut not found")
+		}
+		if request.Command == CommandRun && request.Name == "Unknown" {
+			return WithStatus(StatusUnknownShortcut, errors.New("Shortcut \"Unknown\" not found"))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		in         *Request
+		wantErr    bool
+		wantStatus string
+	}{
+		"show":          {&Request{Command: CommandShow, Id: -1}, false, ""},
+		"run name":      {&Request{Command: CommandRun, Name: "Terminal", Id: -1}, false, ""},
+		"run id":        {&Request{Command: CommandRun, Id: 3}, false, ""},
+		"handler error": {&Request{Command: CommandRun, Id: -1}, true, ""},
+		"status error":  {&Request{Command: CommandRun, Name: "Unknown", Id: -1}, true, StatusUnknownShortcut},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got = nil
+			err := Send(path, testCase.in)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("Send() = %v, want error %t", err, testCase.wantErr)
+			}
+			var statusErr *Error
+			if errors.As(err, &statusErr) && statusErr.Status != testCase.wantStatus {
+				t.Errorf("Send() = status %q, want %q", statusErr.Status, testCase.wantStatus)
+			}
+			if diff := cmp.Diff([]*Request{testCase.in}, got); diff != "" {
+				t.Errorf("Send() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+
+	if err = server.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+	if err = Send(path, &Request{Command: CommandShow}); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Send() = %v, want %v", err, ErrNotRunning)
+	}
+}
+
+func TestListen(t *testing.T) {
+	path := testSocketPath(t)
+	handler := func(*Request) error { return nil }
+	server, err := Listen(path, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Listen(path, handler); !errors.Is(err, ErrAlreadyRunning) {
+		t.Errorf("Listen() = %v, want %v", err, ErrAlreadyRunning)
+	}
+	if err = server.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+	if _, err = os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Close() left the socket behind: %v", err)
+	}
+
+	if err = os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if server, err = Listen(path, handler); err != nil {
+		t.Errorf("Listen() = %v, want a stale socket to be replaced", err)
+	} else {
+		_ = server.Close()
+	}
+
+	if _, err = Listen(filepath.Join(path, "not-exists", socketName+".sock"), handler); err == nil {
+		t.Error("Listen() = error expected")
+	}
+}
+
+func TestInvalidRequest(t *testing.T) {
+	path := testSocketPath(t)
+	server, err := Listen(path, func(*Request) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = server.Close() }()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	if _, err = conn.Write([]byte("invalid\n")); err != nil {
+		t.Fatal(err)
+	}
+	buffer := make([]byte, 256)
+	n, _ := conn.Read(buffer)
+	if got := string(buffer[:n]); got == "" || got == "{}\n" {
+		t.Errorf("handle() = %q, want an error response", got)
+	}
+}
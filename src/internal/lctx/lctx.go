@@ -19,6 +19,7 @@ package lctx
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
@@ -81,6 +82,22 @@ func (runtimeLogger) LogErrorf(message string, args ...interface{}) {
 
 var LoggerImpl Logger = runtimeLogger{}
 
+// ConsoleLogger logs errors to the standard error when there is no runtime, e.g. in the headless mode. Info messages
+// are dropped to keep the output clean for scripts.
+type ConsoleLogger struct{}
+
+func (ConsoleLogger) LogInfo(string) {}
+
+func (ConsoleLogger) LogInfof(string, ...interface{}) {}
+
+func (ConsoleLogger) LogError(message string) {
+	_, _ = fmt.Fprintln(os.Stderr, message)
+}
+
+func (ConsoleLogger) LogErrorf(message string, args ...interface{}) {
+	_, _ = fmt.Fprintf(os.Stderr, message+"\n", args...)
+}
+
 func LogInfo(message string) {
 	LoggerImpl.LogInfo(message)
 }
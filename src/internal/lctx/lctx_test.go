@@ -67,6 +67,14 @@ func TestRuntimeLogger(t *testing.T) {
 	}
 }
 
+func TestConsoleLogger(t *testing.T) {
+	var logger Logger = ConsoleLogger{}
+	logger.LogInfo("test message")
+	logger.LogInfof("test %s", "message")
+	logger.LogError("test message")
+	logger.LogErrorf("test %s", "message")
+}
+
 func TestNewErrorMessageDialog(t *testing.T) {
 	testCases := []string{"valid", "invalid"}
 
@@ -0,0 +1,146 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package socket serves the Unix domain sockets of Launchee, which only the current user can access.
+package socket
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jdheim/launchee/internal/filelock"
+	"github.com/pkg/errors"
+)
+
+const dialTimeout = 5 * time.Second
+
+var ErrInUse = errors.New("Socket is already in use")
+
+// Handler handles a connection, which is closed once it returns.
+type Handler func(conn net.Conn)
+
+type Server struct {
+	listener net.Listener
+	handler  Handler
+	lock     sync.Mutex
+	conns    map[net.Conn]bool
+	wg       sync.WaitGroup
+}
+
+// Path returns the path of the socket with the given name under $XDG_RUNTIME_DIR or, when it is not set, under the
+// dir of the user in the temp dir, named by the user id.
+func Path(name string) string {
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, name+".sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("launchee-%d", os.Getuid()), name+".sock")
+}
+
+// Listen starts serving the connections on the socket. It returns ErrInUse when another process is listening on it
+// and removes the socket left behind by a process that has not exited cleanly. The check and the bind are done
+// holding the lock file next to the socket, so two starting processes cannot both take the socket over. The dir of
+// the socket is created when missing and must be accessible only by the user, so no other user can take it over.
+func Listen(path string, handler Handler) (*Server, error) {
+	if err := makePrivateDir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	unlock, err := filelock.Lock(path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if Listening(path) {
+		return nil, ErrInUse
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(path, 0o600); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+	server := &Server{listener: listener, handler: handler, conns: make(map[net.Conn]bool)}
+	server.wg.Add(1)
+	go server.serve()
+	return server, nil
+}
+
+// Listening tells whether a process is listening on the socket.
+func Listening(path string) bool {
+	conn, err := Dial(path, dialTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// Dial connects to the socket, unless its dir is accessible by other users, who could have put it there.
+func Dial(path string, timeout time.Duration) (net.Conn, error) {
+	if err := checkPrivateDir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	return net.DialTimeout("unix", path, timeout)
+}
+
+func makePrivateDir(dir string) error {
+	if err := os.Mkdir(dir, 0o700); err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	return checkPrivateDir(dir)
+}
+
+// Close stops accepting the connections, closes the open ones, waits for their handlers and removes the socket.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.lock.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.lock.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.lock.Lock()
+		s.conns[conn] = true
+		s.lock.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handler(conn)
+			_ = conn.Close()
+			s.lock.Lock()
+			delete(s.conns, conn)
+			s.lock.Unlock()
+		}()
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package socket
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/jdheim/launchee/internal/test/tempdir"
+	"github.com/pkg/errors"
+)
+
+func testSocketPath(t *testing.T) string {
+	t.Helper()
+	return filepath.Join(tempdir.Short(t), "launchee.sock")
+}
+
+func TestPath(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	if got, want := Path("launchee"), "/run/user/1000/launchee.sock"; got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	want := filepath.Join(os.TempDir(), fmt.Sprintf("launchee-%d", os.Getuid()), "launchee.sock")
+	if got := Path("launchee"); got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+}
+
+func TestListen(t *testing.T) {
+	path := testSocketPath(t)
+	handled := make(chan bool, 1)
+	server, err := Listen(path, func(net.Conn) { handled <- true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Listening(path) {
+		t.Error("Listening() = false, want true")
+	}
+	if _, err = Listen(path, func(net.Conn) {}); !errors.Is(err, ErrInUse) {
+		t.Errorf("Listen() = %v, want %v", err, ErrInUse)
+	}
+	<-handled
+	if err = server.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+	if Listening(path) {
+		t.Error("Listening() = true after Close(), want false")
+	}
+}
+
+func TestListenInSharedDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows permissions are not reflected in the file mode")
+	}
+	path := testSocketPath(t)
+	if err := os.Chmod(filepath.Dir(path), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if server, err := Listen(path, func(net.Conn) {}); err == nil {
+		_ = server.Close()
+		t.Error("Listen() = nil, want error for the dir other users can write")
+	}
+	if conn, err := Dial(path, dialTimeout); err == nil {
+		_ = conn.Close()
+		t.Error("Dial() = nil, want error for the dir other users can write")
+	}
+}
+
+func TestListenCreatesDir(t *testing.T) {
+	path := filepath.Join(filepath.Dir(testSocketPath(t)), "launchee-1000", "launchee.sock")
+	server, err := Listen(path, func(net.Conn) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = server.Close()
+	if info, err := os.Stat(filepath.Dir(path)); err != nil || runtime.GOOS != "windows" && info.Mode().Perm() != 0o700 {
+		t.Errorf("Listen() created %q with %v, want mode 0700", filepath.Dir(path), err)
+	}
+}
+
+func TestListenConcurrently(t *testing.T) {
+	path := testSocketPath(t)
+	servers := make(chan *Server, 8)
+	var wg sync.WaitGroup
+	for range cap(servers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server, err := Listen(path, func(net.Conn) {})
+			if err == nil {
+				servers <- server
+			} else if !errors.Is(err, ErrInUse) {
+				t.Errorf("Listen() = %v, want %v", err, ErrInUse)
+			}
+		}()
+	}
+	wg.Wait()
+	close(servers)
+	count := 0
+	for server := range servers {
+		count++
+		_ = server.Close()
+	}
+	if count != 1 {
+		t.Errorf("Listen() succeeded %d times, want 1", count)
+	}
+}
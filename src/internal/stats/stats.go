@@ -0,0 +1,151 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package stats keeps how often and when each shortcut was launched in a small local store.
+package stats
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/jdheim/launchee/internal/filelock"
+	"github.com/pkg/errors"
+)
+
+const (
+	storeDir  = "launchee"
+	storeFile = "stats.json"
+)
+
+type Usage struct {
+	Launches   int       `json:"launches"`
+	LastLaunch time.Time `json:"lastLaunch"`
+}
+
+// Store reads and writes the usage of the shortcuts, keyed by their stable ids. It is read again on every access and
+// the launches are recorded holding the lock file next to it, so the ones recorded by other processes, e.g.
+// `launchee run`, are not lost.
+type Store struct {
+	path func() string
+	lock sync.Mutex
+}
+
+var getGOOS = func() string {
+	return runtime.GOOS
+}
+
+// NewStore returns the store at the path, which is resolved on every access.
+func NewStore(path func() string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the path of the store under $XDG_DATA_HOME or, when it is not set, under ~/.local/share. Other
+// systems use the user config dir.
+func Path() string {
+	var dataDir string
+	if xdgDataDir := os.Getenv("XDG_DATA_HOME"); xdgDataDir != "" {
+		dataDir = xdgDataDir
+	} else if homeDir, err := os.UserHomeDir(); err == nil && getGOOS() == "linux" {
+		dataDir = filepath.Join(homeDir, ".local", "share")
+	} else if configDir, err := os.UserConfigDir(); err == nil {
+		dataDir = configDir
+	} else {
+		dataDir = os.TempDir()
+	}
+	return filepath.Join(dataDir, storeDir, storeFile)
+}
+
+// Load returns the usage of the shortcuts. A missing store is empty. A corrupted one is empty as well, but the error
+// is returned too, so it can be reported.
+func (s *Store) Load() (map[string]*Usage, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.load()
+}
+
+// Record counts a launch of the shortcut with the stable id at the time.
+func (s *Store) Record(id string, at time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	path := s.path()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	unlock, err := filelock.Lock(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	// A corrupted store is replaced, as there is nothing to recover from it
+	usage, _ := s.load()
+	if usage[id] == nil {
+		usage[id] = &Usage{}
+	}
+	usage[id].Launches++
+	usage[id].LastLaunch = at
+	return s.save(usage)
+}
+
+func (s *Store) load() (map[string]*Usage, error) {
+	path := s.path()
+	usage := make(map[string]*Usage)
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return usage, nil
+	} else if err != nil {
+		return usage, errors.WithMessagef(err, "Could not read %s", path)
+	}
+	if err = json.Unmarshal(bytes, &usage); err != nil {
+		return make(map[string]*Usage), errors.WithMessagef(err, "Could not parse %s", path)
+	}
+	for name, shortcutUsage := range usage {
+		if shortcutUsage == nil {
+			delete(usage, name)
+		}
+	}
+	return usage, nil
+}
+
+// save writes the usage to a temp file first, which then replaces the store, so it is never written partially.
+func (s *Store) save(usage map[string]*Usage) error {
+	path := s.path()
+	dir := filepath.Dir(path)
+	bytes, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(dir, storeFile+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tempFile.Name()) }()
+	if _, err = tempFile.Write(bytes); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err = tempFile.Sync(); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), path)
+}
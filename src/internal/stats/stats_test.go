@@ -0,0 +1,181 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPath(t *testing.T) {
+	homeDir, _ := os.UserHomeDir()
+	configDir, _ := os.UserConfigDir()
+	testCases := map[string]struct {
+		xdgDataHome string
+		goos        string
+		want        string
+	}{
+		"xdg data home": {"/data", "linux", filepath.Join("/data", "launchee", "stats.json")},
+		"linux":         {"", "linux", filepath.Join(homeDir, ".local", "share", "launchee", "stats.json")},
+		"other":         {"", "darwin", filepath.Join(configDir, "launchee", "stats.json")},
+	}
+
+	originalGetGOOS := getGOOS
+	defer func() { getGOOS = originalGetGOOS }()
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("XDG_DATA_HOME", testCase.xdgDataHome)
+			getGOOS = func() string { return testCase.goos }
+			if got := Path(); got != testCase.want {
+				t.Errorf("Path() = %s, want %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	lastLaunch := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := map[string]struct {
+		in      *string
+		want    map[string]*Usage
+		wantErr bool
+	}{
+		"missing":   {nil, map[string]*Usage{}, false},
+		"empty":     {ptr("{}"), map[string]*Usage{}, false},
+		"corrupted": {ptr(`{"Terminal": {"launches": 3`), map[string]*Usage{}, true},
+		"null":      {ptr(`{"Terminal": null}`), map[string]*Usage{}, false},
+		"valid": {ptr(`{"Terminal": {"launches": 3, "lastLaunch": "2025-01-02T03:04:05Z"}}`),
+			map[string]*Usage{"Terminal": {Launches: 3, LastLaunch: lastLaunch}}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "stats.json")
+			if testCase.in != nil {
+				if err := os.WriteFile(path, []byte(*testCase.in), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := NewStore(func() string { return path }).Load()
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("Load() = %v, want error %t", err, testCase.wantErr)
+			}
+			if diff := cmp.Diff(testCase.want, got); diff != "" {
+				t.Errorf("Load() = diff -want +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "launchee", "stats.json")
+	store := NewStore(func() string { return path })
+	first := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	for _, record := range []struct {
+		name string
+		at   time.Time
+	}{{"Terminal", first}, {"Browser", first}, {"Terminal", second}} {
+		if err := store.Record(record.name, record.at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]*Usage{
+		"Terminal": {Launches: 2, LastLaunch: second},
+		"Browser":  {Launches: 1, LastLaunch: first},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Record() = diff -want +got\n%s", diff)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Record() left %d files, want only the store and its lock file", len(entries))
+	}
+}
+
+// TestRecordConcurrently records with a store per goroutine, the same as the processes recording to the same file.
+func TestRecordConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	const count = 20
+	var wg sync.WaitGroup
+	for range count {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := NewStore(func() string { return path }).Record("term", at); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	got, err := NewStore(func() string { return path }).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]*Usage{"term": {Launches: count, LastLaunch: at}}, got); diff != "" {
+		t.Errorf("Record() = diff -want +got\n%s", diff)
+	}
+}
+
+func TestRecordCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := NewStore(func() string { return path })
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := store.Record("Terminal", at); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]*Usage{"Terminal": {Launches: 1, LastLaunch: at}}, got); diff != "" {
+		t.Errorf("Record() = diff -want +got\n%s", diff)
+	}
+}
+
+func TestRecordNotWritable(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewStore(func() string { return filepath.Join(file, "stats.json") }).Record("Terminal", time.Now()); err == nil {
+		t.Error("Record() = error expected")
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
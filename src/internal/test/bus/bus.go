@@ -0,0 +1,95 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package bus starts a private D-Bus session bus and the services the tests expect on it.
+package bus
+
+import (
+	"bufio"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// StartSessionBus starts a dbus-daemon that is stopped once the test finishes and points
+// DBUS_SESSION_BUS_ADDRESS to it. The test is skipped when dbus-daemon is not installed.
+func StartSessionBus(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("dbus-daemon"); err != nil {
+		t.Skip("dbus-daemon is not installed")
+	}
+	cmd := exec.Command("dbus-daemon", "--session", "--nofork", "--print-address")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	address = strings.TrimSpace(address)
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", address)
+	return address
+}
+
+// Watcher records the tray icons registered with the fake StatusNotifierWatcher.
+type Watcher struct {
+	lock  sync.Mutex
+	items []string
+}
+
+func (w *Watcher) RegisterStatusNotifierItem(service string) *dbus.Error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.items = append(w.items, service)
+	return nil
+}
+
+// Items returns the bus names of the registered tray icons.
+func (w *Watcher) Items() []string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return append([]string(nil), w.items...)
+}
+
+// ServeStatusNotifierWatcher serves a fake StatusNotifierWatcher, which desktops with a tray provide, on the session
+// bus started by StartSessionBus.
+func ServeStatusNotifierWatcher(t *testing.T) *Watcher {
+	t.Helper()
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	watcher := &Watcher{}
+	if err = conn.Export(watcher, "/StatusNotifierWatcher", "org.kde.StatusNotifierWatcher"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = conn.RequestName("org.kde.StatusNotifierWatcher", dbus.NameFlagDoNotQueue); err != nil {
+		t.Fatal(err)
+	}
+	return watcher
+}
@@ -0,0 +1,53 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bus
+
+import (
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestStartSessionBus(t *testing.T) {
+	address := StartSessionBus(t)
+	if address == "" {
+		t.Error("StartSessionBus() = empty address")
+	}
+	if got := os.Getenv("DBUS_SESSION_BUS_ADDRESS"); got != address {
+		t.Errorf("DBUS_SESSION_BUS_ADDRESS = %s, want %s", got, address)
+	}
+}
+
+func TestServeStatusNotifierWatcher(t *testing.T) {
+	StartSessionBus(t)
+	watcher := ServeStatusNotifierWatcher(t)
+
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	call := conn.Object("org.kde.StatusNotifierWatcher", "/StatusNotifierWatcher").
+		Call("org.kde.StatusNotifierWatcher.RegisterStatusNotifierItem", 0, "org.kde.StatusNotifierItem-1-1")
+	if call.Err != nil {
+		t.Fatal(call.Err)
+	}
+	if items := watcher.Items(); len(items) != 1 || items[0] != "org.kde.StatusNotifierItem-1-1" {
+		t.Errorf("Items() = %v, want [org.kde.StatusNotifierItem-1-1]", items)
+	}
+}
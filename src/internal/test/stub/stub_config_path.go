@@ -19,6 +19,8 @@ package stub
 const launchee7ConfigPath = "internal/test/stub/stub_config/launchee-7.yml"
 const launcheeOverrideConfigPath = "internal/test/stub/stub_config/launchee-override.yml"
 const launcheeEmptyConfigPath = "internal/test/stub/stub_config/launchee-empty.yml"
+const launcheeBrokenConfigPath = "internal/test/stub/stub_config/launchee-broken.yml"
+const launcheeLenientConfigPath = "internal/test/stub/stub_config/launchee-lenient.yml"
 const invalidConfigPath = "/usr/bin/echo"
 const notExistsConfigPath = "/tmp/not-exists.yml"
 
@@ -121,3 +123,23 @@ func (UserConfigPathEmptyStub) GetSystemConfigPath() string {
 func (UserConfigPathEmptyStub) GetUserConfigPath() string {
 	return launcheeEmptyConfigPath
 }
+
+type SystemConfigPathBrokenStub struct{}
+
+func (SystemConfigPathBrokenStub) GetSystemConfigPath() string {
+	return launcheeBrokenConfigPath
+}
+
+func (SystemConfigPathBrokenStub) GetUserConfigPath() string {
+	return launcheeEmptyConfigPath
+}
+
+type ConfigPathLenientStub struct{}
+
+func (ConfigPathLenientStub) GetSystemConfigPath() string {
+	return launcheeBrokenConfigPath
+}
+
+func (ConfigPathLenientStub) GetUserConfigPath() string {
+	return launcheeLenientConfigPath
+}
@@ -37,6 +37,8 @@ func TestConfigPathStub(t *testing.T) {
 		"empty":             {ConfigPathEmptyStub{}, []string{launcheeEmptyConfigPath, launcheeEmptyConfigPath}},
 		"system empty":      {SystemConfigPathEmptyStub{}, []string{launcheeEmptyConfigPath, launchee7ConfigPath}},
 		"user empty":        {UserConfigPathEmptyStub{}, []string{launchee7ConfigPath, launcheeEmptyConfigPath}},
+		"system broken":     {SystemConfigPathBrokenStub{}, []string{launcheeBrokenConfigPath, launcheeEmptyConfigPath}},
+		"lenient":           {ConfigPathLenientStub{}, []string{launcheeBrokenConfigPath, launcheeLenientConfigPath}},
 	}
 
 	for name, testCase := range testCases {
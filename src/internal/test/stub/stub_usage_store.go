@@ -0,0 +1,53 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stub
+
+import (
+	"log"
+	"time"
+
+	"github.com/jdheim/launchee/internal/stats"
+	"github.com/jdheim/launchee/internal/test/debug"
+	"github.com/pkg/errors"
+)
+
+// UsageStoreStub has "Firefox" launched the most and "Terminal" the most recently.
+type UsageStoreStub struct{}
+
+func (UsageStoreStub) Load() (map[string]*stats.Usage, error) {
+	return map[string]*stats.Usage{
+		"Firefox":  {Launches: 5, LastLaunch: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"Terminal": {Launches: 2, LastLaunch: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}, nil
+}
+
+func (UsageStoreStub) Record(name string, at time.Time) error {
+	if debug.IsDebugEnabled() {
+		log.Printf("Record: %s at %v", name, at)
+	}
+	return nil
+}
+
+type UsageStoreCorruptedStub struct{}
+
+func (UsageStoreCorruptedStub) Load() (map[string]*stats.Usage, error) {
+	return map[string]*stats.Usage{}, errors.New("Could not parse stats.json")
+}
+
+func (UsageStoreCorruptedStub) Record(_ string, _ time.Time) error {
+	return errors.New("Could not write stats.json")
+}
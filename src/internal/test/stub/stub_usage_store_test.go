@@ -0,0 +1,43 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdheim/launchee/internal/test/debug"
+)
+
+func TestUsageStoreStub(t *testing.T) {
+	debug.EnableDebug()
+	if usage, err := (UsageStoreStub{}).Load(); err != nil || len(usage) != 2 {
+		t.Errorf("Load() = %v, %v, want 2 shortcuts, nil", usage, err)
+	}
+	if err := (UsageStoreStub{}).Record("Terminal", time.Now()); err != nil {
+		t.Errorf("Record() = %v, want nil", err)
+	}
+}
+
+func TestUsageStoreCorruptedStub(t *testing.T) {
+	if usage, err := (UsageStoreCorruptedStub{}).Load(); err == nil || len(usage) != 0 {
+		t.Errorf("Load() = %v, %v, want no shortcuts, error", usage, err)
+	}
+	if err := (UsageStoreCorruptedStub{}).Record("Terminal", time.Now()); err == nil {
+		t.Error("Record() = error expected")
+	}
+}
@@ -48,6 +48,24 @@ func (WindowStub) SetMaxSize(width int, height int) {
 	}
 }
 
+func (WindowStub) Show() {
+	if debug.IsDebugEnabled() {
+		log.Printf("Showing")
+	}
+}
+
+func (WindowStub) Hide() {
+	if debug.IsDebugEnabled() {
+		log.Printf("Hiding")
+	}
+}
+
+func (WindowStub) Minimise() {
+	if debug.IsDebugEnabled() {
+		log.Printf("Minimising")
+	}
+}
+
 func (WindowStub) Quit() {
 	if debug.IsDebugEnabled() {
 		log.Printf("Quiting")
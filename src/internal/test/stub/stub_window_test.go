@@ -38,6 +38,15 @@ func TestWindowStub(t *testing.T) {
 		"SetMaxSize": {func() {
 			WindowStub{}.SetMaxSize(0, 0)
 		}},
+		"Show": {func() {
+			WindowStub{}.Show()
+		}},
+		"Hide": {func() {
+			WindowStub{}.Hide()
+		}},
+		"Minimise": {func() {
+			WindowStub{}.Minimise()
+		}},
 		"Quit": {func() {
 			WindowStub{}.Quit()
 		}},
@@ -0,0 +1,34 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tempdir
+
+import (
+	"os"
+	"testing"
+)
+
+// Short returns a temp dir removed once the test ends, e.g. for Unix sockets, whose paths are limited to ~100
+// characters, which t.TempDir() may exceed.
+func Short(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "launchee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return dir
+}
@@ -0,0 +1,38 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tempdir
+
+import (
+	"os"
+	"testing"
+)
+
+func TestShort(t *testing.T) {
+	var dir string
+	t.Run("dir", func(t *testing.T) {
+		dir = Short(t)
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			t.Errorf("Short() = %q, want an existing dir: %v", dir, err)
+		}
+		if len(dir) > 64 {
+			t.Errorf("Short() = %q, want at most 64 characters", dir)
+		}
+	})
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("Short() = %q, want it removed once the test ends: %v", dir, err)
+	}
+}
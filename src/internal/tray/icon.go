@@ -0,0 +1,85 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tray
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/pkg/errors"
+)
+
+// iconSize is the size of the tray icon, which the tray scales further as needed.
+const iconSize = 64
+
+// pixmap is an icon in ARGB32 with the bytes in network order, as the tray expects.
+type pixmap struct {
+	Width  int32
+	Height int32
+	Data   []byte
+}
+
+// toPixmaps decodes the PNG icon and scales it down to the tray icon size.
+func toPixmaps(icon []byte) ([]pixmap, error) {
+	img, err := png.Decode(bytes.NewReader(icon))
+	if err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return nil, errors.New("Icon is empty")
+	}
+	width, height := iconSize, iconSize
+	if bounds.Dx() < width || bounds.Dy() < height {
+		width, height = bounds.Dx(), bounds.Dy()
+	}
+	data := make([]byte, 0, width*height*4)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := averageColor(img, image.Rect(
+				bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height,
+				bounds.Min.X+(x+1)*bounds.Dx()/width, bounds.Min.Y+(y+1)*bounds.Dy()/height,
+			))
+			data = append(data, c.A, c.R, c.G, c.B)
+		}
+	}
+	return []pixmap{{Width: int32(width), Height: int32(height), Data: data}}, nil
+}
+
+// averageColor returns the non-premultiplied average of the pixels in the area.
+func averageColor(img image.Image, area image.Rectangle) color.NRGBA {
+	var r, g, b, a, count uint64
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			pr, pg, pb, pa := img.At(x, y).RGBA()
+			r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+			count++
+		}
+	}
+	if count == 0 || a == 0 {
+		return color.NRGBA{}
+	}
+	// The colors are premultiplied by alpha, so they are divided by the total alpha to undo it
+	return color.NRGBA{
+		R: uint8(r * 0xff / a),
+		G: uint8(g * 0xff / a),
+		B: uint8(b * 0xff / a),
+		A: uint8((a / count) >> 8),
+	}
+}
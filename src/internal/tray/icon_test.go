@@ -0,0 +1,86 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tray
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jdheim/launchee/build"
+)
+
+func encodePng(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestToPixmaps(t *testing.T) {
+	small := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	small.SetNRGBA(0, 0, color.NRGBA{R: 0x11, G: 0x22, B: 0x33, A: 0xff})
+	small.SetNRGBA(1, 0, color.NRGBA{R: 0xff, A: 0x80})
+	large := image.NewNRGBA(image.Rect(0, 0, 128, 128))
+	for y := 0; y < 128; y++ {
+		for x := 0; x < 128; x++ {
+			large.SetNRGBA(x, y, color.NRGBA{B: 0xff, A: 0xff})
+		}
+	}
+
+	testCases := map[string]struct {
+		in         []byte
+		wantWidth  int32
+		wantHeight int32
+		wantFirst  []byte
+	}{
+		"small is kept":      {encodePng(t, small), 2, 1, []byte{0xff, 0x11, 0x22, 0x33, 0x80, 0xff, 0x00, 0x00}},
+		"large is scaled":    {encodePng(t, large), iconSize, iconSize, []byte{0xff, 0x00, 0x00, 0xff}},
+		"app icon is scaled": {build.GetAppIconBytes(), iconSize, iconSize, nil},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := toPixmaps(testCase.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || got[0].Width != testCase.wantWidth || got[0].Height != testCase.wantHeight {
+				t.Fatalf("toPixmaps() = %d pixmaps, want one %dx%d", len(got), testCase.wantWidth, testCase.wantHeight)
+			}
+			if len(got[0].Data) != int(testCase.wantWidth*testCase.wantHeight*4) {
+				t.Errorf("toPixmaps() data = %d bytes, want %d", len(got[0].Data), testCase.wantWidth*testCase.wantHeight*4)
+			}
+			if testCase.wantFirst != nil {
+				if diff := cmp.Diff(testCase.wantFirst, got[0].Data[:len(testCase.wantFirst)]); diff != "" {
+					t.Errorf("toPixmaps() = diff -want +got\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestToPixmapsInvalid(t *testing.T) {
+	if _, err := toPixmaps([]byte("not a png")); err == nil {
+		t.Error("toPixmaps() = error expected")
+	}
+}
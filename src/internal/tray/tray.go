@@ -0,0 +1,276 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tray shows a StatusNotifierItem tray icon with a DBusMenu menu on the session bus.
+package tray
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+	"github.com/pkg/errors"
+)
+
+const (
+	itemPath         = dbus.ObjectPath("/StatusNotifierItem")
+	itemInterface    = "org.kde.StatusNotifierItem"
+	menuPath         = dbus.ObjectPath("/MenuBar")
+	menuInterface    = "com.canonical.dbusmenu"
+	watcherName      = "org.kde.StatusNotifierWatcher"
+	watcherPath      = dbus.ObjectPath("/StatusNotifierWatcher")
+	watcherInterface = "org.kde.StatusNotifierWatcher"
+)
+
+type MenuItem struct {
+	Label     string
+	Separator bool
+	OnClick   func()
+}
+
+// Separator returns a menu item that separates the groups of items.
+func Separator() *MenuItem {
+	return &MenuItem{Separator: true}
+}
+
+type Tray struct {
+	conn       *dbus.Conn
+	onActivate func()
+	lock       sync.Mutex
+	items      []*MenuItem
+	revision   uint32
+}
+
+// Start shows the tray icon, which runs onActivate when clicked and shows the items in its menu.
+func Start(icon []byte, title string, onActivate func(), items []*MenuItem) (*Tray, error) {
+	pixmaps, err := toPixmaps(icon)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not read the tray icon")
+	}
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Could not connect to the session bus")
+	}
+	tray := &Tray{conn: conn, onActivate: onActivate, items: items, revision: 1}
+	if err = tray.export(pixmaps, title); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return tray, nil
+}
+
+func (t *Tray) export(pixmaps []pixmap, title string) error {
+	if err := t.conn.Export(item{tray: t}, itemPath, itemInterface); err != nil {
+		return err
+	}
+	if _, err := prop.Export(t.conn, itemPath, prop.Map{itemInterface: {
+		"Category":            {Value: "ApplicationStatus"},
+		"Id":                  {Value: "launchee"},
+		"Title":               {Value: title},
+		"Status":              {Value: "Active"},
+		"WindowId":            {Value: int32(0)},
+		"IconName":            {Value: ""},
+		"IconPixmap":          {Value: pixmaps},
+		"OverlayIconName":     {Value: ""},
+		"OverlayIconPixmap":   {Value: []pixmap{}},
+		"AttentionIconName":   {Value: ""},
+		"AttentionIconPixmap": {Value: []pixmap{}},
+		"ToolTip":             {Value: toolTip{IconPixmap: []pixmap{}, Title: title}},
+		"ItemIsMenu":          {Value: false},
+		"Menu":                {Value: menuPath},
+	}}); err != nil {
+		return err
+	}
+	if err := t.conn.Export(menu{tray: t}, menuPath, menuInterface); err != nil {
+		return err
+	}
+	if _, err := prop.Export(t.conn, menuPath, prop.Map{menuInterface: {
+		"Version":       {Value: uint32(3)},
+		"TextDirection": {Value: "ltr"},
+		"Status":        {Value: "normal"},
+		"IconThemePath": {Value: []string{}},
+	}}); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("org.kde.StatusNotifierItem-%d-1", os.Getpid())
+	if _, err := t.conn.RequestName(name, dbus.NameFlagDoNotQueue); err != nil {
+		return err
+	}
+	watcher := t.conn.Object(watcherName, watcherPath)
+	if err := watcher.Call(watcherInterface+".RegisterStatusNotifierItem", 0, name).Err; err != nil {
+		return errors.WithMessage(err, "Could not register the tray icon, the desktop may have no tray")
+	}
+	return nil
+}
+
+// SetMenu replaces the items of the menu.
+func (t *Tray) SetMenu(items []*MenuItem) {
+	t.lock.Lock()
+	t.items = items
+	t.revision++
+	revision := t.revision
+	t.lock.Unlock()
+	_ = t.conn.Emit(menuPath, menuInterface+".LayoutUpdated", revision, int32(0))
+}
+
+// Close removes the tray icon.
+func (t *Tray) Close() error {
+	return t.conn.Close()
+}
+
+// click runs the menu item with the id, which is its position in the menu starting from 1.
+func (t *Tray) click(id int32) {
+	t.lock.Lock()
+	var onClick func()
+	if id > 0 && int(id) <= len(t.items) {
+		onClick = t.items[id-1].OnClick
+	}
+	t.lock.Unlock()
+	// The lock is released first, as the item may replace the menu
+	if onClick != nil {
+		onClick()
+	}
+}
+
+// item implements org.kde.StatusNotifierItem.
+type item struct {
+	tray *Tray
+}
+
+func (i item) Activate(_ int32, _ int32) *dbus.Error {
+	if i.tray.onActivate != nil {
+		i.tray.onActivate()
+	}
+	return nil
+}
+
+func (i item) SecondaryActivate(_ int32, _ int32) *dbus.Error {
+	return nil
+}
+
+func (i item) ContextMenu(_ int32, _ int32) *dbus.Error {
+	return nil
+}
+
+func (i item) Scroll(_ int32, _ string) *dbus.Error {
+	return nil
+}
+
+type toolTip struct {
+	IconName    string
+	IconPixmap  []pixmap
+	Title       string
+	Description string
+}
+
+// menu implements com.canonical.dbusmenu. The root has id 0 and the items have their position, starting from 1.
+type menu struct {
+	tray *Tray
+}
+
+type layout struct {
+	Id         int32
+	Properties map[string]dbus.Variant
+	Children   []dbus.Variant
+}
+
+type itemProperties struct {
+	Id         int32
+	Properties map[string]dbus.Variant
+}
+
+type event struct {
+	Id        int32
+	EventId   string
+	Data      dbus.Variant
+	Timestamp uint32
+}
+
+func (m menu) GetLayout(parentId int32, _ int32, _ []string) (uint32, layout, *dbus.Error) {
+	m.tray.lock.Lock()
+	defer m.tray.lock.Unlock()
+	if parentId != 0 {
+		return m.tray.revision, layout{Id: parentId, Properties: m.properties(parentId), Children: []dbus.Variant{}}, nil
+	}
+	root := layout{Id: 0, Properties: map[string]dbus.Variant{"children-display": dbus.MakeVariant("submenu")}}
+	root.Children = make([]dbus.Variant, 0, len(m.tray.items))
+	for i := range m.tray.items {
+		id := int32(i + 1)
+		root.Children = append(root.Children, dbus.MakeVariant(layout{
+			Id:         id,
+			Properties: m.properties(id),
+			Children:   []dbus.Variant{},
+		}))
+	}
+	return m.tray.revision, root, nil
+}
+
+func (m menu) GetGroupProperties(ids []int32, _ []string) ([]itemProperties, *dbus.Error) {
+	m.tray.lock.Lock()
+	defer m.tray.lock.Unlock()
+	group := make([]itemProperties, 0, len(ids))
+	for _, id := range ids {
+		group = append(group, itemProperties{Id: id, Properties: m.properties(id)})
+	}
+	return group, nil
+}
+
+func (m menu) GetProperty(id int32, name string) (dbus.Variant, *dbus.Error) {
+	m.tray.lock.Lock()
+	defer m.tray.lock.Unlock()
+	if value, ok := m.properties(id)[name]; ok {
+		return value, nil
+	}
+	return dbus.Variant{}, dbus.MakeFailedError(errors.Errorf("Menu item %d has no property %s", id, name))
+}
+
+func (m menu) Event(id int32, eventId string, _ dbus.Variant, _ uint32) *dbus.Error {
+	if eventId == "clicked" {
+		m.tray.click(id)
+	}
+	return nil
+}
+
+func (m menu) EventGroup(events []event) ([]int32, *dbus.Error) {
+	for _, e := range events {
+		_ = m.Event(e.Id, e.EventId, e.Data, e.Timestamp)
+	}
+	return []int32{}, nil
+}
+
+func (m menu) AboutToShow(_ int32) (bool, *dbus.Error) {
+	return false, nil
+}
+
+func (m menu) AboutToShowGroup(_ []int32) ([]int32, []int32, *dbus.Error) {
+	return []int32{}, []int32{}, nil
+}
+
+// properties returns the properties of the menu item, which must be called with the lock held.
+func (m menu) properties(id int32) map[string]dbus.Variant {
+	if id <= 0 || int(id) > len(m.tray.items) {
+		return map[string]dbus.Variant{}
+	}
+	menuItem := m.tray.items[id-1]
+	if menuItem.Separator {
+		return map[string]dbus.Variant{"type": dbus.MakeVariant("separator")}
+	}
+	// Underscores mark the mnemonics in the labels
+	return map[string]dbus.Variant{"label": dbus.MakeVariant(strings.ReplaceAll(menuItem.Label, "_", "__"))}
+}
@@ -0,0 +1,145 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tray
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/jdheim/launchee/build"
+	"github.com/jdheim/launchee/internal/test/bus"
+)
+
+func TestStart(t *testing.T) {
+	bus.StartSessionBus(t)
+	watcher := bus.ServeStatusNotifierWatcher(t)
+
+	var activated, clicked atomic.Int32
+	tray, err := Start(build.GetAppIconBytes(), "Launchee", func() { activated.Add(1) }, []*MenuItem{
+		{Label: "Terminal_1", OnClick: func() { clicked.Add(1) }},
+		Separator(),
+		{Label: "Quit"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tray.Close() }()
+
+	name := fmt.Sprintf("org.kde.StatusNotifierItem-%d-1", os.Getpid())
+	if items := watcher.Items(); len(items) != 1 || items[0] != name {
+		t.Errorf("registered items = %v, want [%s]", items, name)
+	}
+
+	client, err := dbus.ConnectSessionBus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Close() }()
+	itemObject := client.Object(name, itemPath)
+	menuObject := client.Object(name, menuPath)
+
+	title, err := itemObject.GetProperty(itemInterface + ".Title")
+	if err != nil || title.Value() != "Launchee" {
+		t.Errorf("Title = %v, %v, want Launchee", title, err)
+	}
+	if err = itemObject.Call(itemInterface+".Activate", 0, int32(0), int32(0)).Err; err != nil {
+		t.Fatal(err)
+	}
+	if activated.Load() != 1 {
+		t.Errorf("activated = %d, want 1", activated.Load())
+	}
+
+	var revision uint32
+	var root layout
+	if err = menuObject.Call(menuInterface+".GetLayout", 0, int32(0), int32(-1), []string{}).
+		Store(&revision, &root); err != nil {
+		t.Fatal(err)
+	}
+	if len(root.Children) != 3 {
+		t.Fatalf("GetLayout() children = %d, want 3", len(root.Children))
+	}
+	var first layout
+	if err = dbus.Store([]any{root.Children[0].Value()}, &first); err != nil {
+		t.Fatal(err)
+	}
+	if label := first.Properties["label"].Value(); first.Id != 1 || label != "Terminal__1" {
+		t.Errorf("first item = %d %v, want 1 Terminal__1", first.Id, label)
+	}
+
+	if err = menuObject.Call(menuInterface+".Event", 0, int32(1), "clicked", dbus.MakeVariant(""), uint32(0)).
+		Err; err != nil {
+		t.Fatal(err)
+	}
+	// Clicking the separator or an unknown item does nothing
+	for _, id := range []int32{2, 3, 7} {
+		if err = menuObject.Call(menuInterface+".Event", 0, id, "clicked", dbus.MakeVariant(""), uint32(0)).
+			Err; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if clicked.Load() != 1 {
+		t.Errorf("clicked = %d, want 1", clicked.Load())
+	}
+}
+
+func TestSetMenu(t *testing.T) {
+	bus.StartSessionBus(t)
+	bus.ServeStatusNotifierWatcher(t)
+	tray, err := Start(build.GetAppIconBytes(), "Launchee", nil, []*MenuItem{{Label: "Quit"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tray.Close() }()
+
+	client, err := dbus.ConnectSessionBus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Close() }()
+	if err = client.AddMatchSignal(dbus.WithMatchInterface(menuInterface)); err != nil {
+		t.Fatal(err)
+	}
+	signals := make(chan *dbus.Signal, 1)
+	client.Signal(signals)
+
+	tray.SetMenu([]*MenuItem{{Label: "Terminal"}, {Label: "Quit"}})
+	select {
+	case signal := <-signals:
+		if len(signal.Body) != 2 || signal.Body[0] != uint32(2) {
+			t.Errorf("LayoutUpdated = %v, want [2 0]", signal.Body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("LayoutUpdated signal was not received")
+	}
+}
+
+func TestStartWithoutWatcher(t *testing.T) {
+	bus.StartSessionBus(t)
+	if _, err := Start(build.GetAppIconBytes(), "Launchee", nil, nil); err == nil {
+		t.Error("Start() = error expected")
+	}
+}
+
+func TestStartInvalidIcon(t *testing.T) {
+	if _, err := Start([]byte("not a png"), "Launchee", nil, nil); err == nil {
+		t.Error("Start() = error expected")
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package xbel reads the recently used files stored in the XBEL format, as specified by the freedesktop.org Desktop
+// Bookmark Specification and written by GTK applications.
+package xbel
+
+import (
+	"encoding/xml"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const recentFile = "recently-used.xbel"
+
+type Bookmark struct {
+	Href         string
+	Added        time.Time
+	Modified     time.Time
+	Visited      time.Time
+	MimeType     string
+	Applications []Application
+}
+
+type Application struct {
+	Name     string    `xml:"name,attr"`
+	Exec     string    `xml:"exec,attr"`
+	Modified time.Time `xml:"modified,attr"`
+	Count    int       `xml:"count,attr"`
+}
+
+// Reader reads the recently used files from the file at the path.
+type Reader struct {
+	path func() string
+}
+
+type document struct {
+	Bookmarks []struct {
+		Href     string    `xml:"href,attr"`
+		Added    time.Time `xml:"added,attr"`
+		Modified time.Time `xml:"modified,attr"`
+		Visited  time.Time `xml:"visited,attr"`
+		Metadata struct {
+			MimeType struct {
+				Type string `xml:"type,attr"`
+			} `xml:"http://www.freedesktop.org/standards/shared-mime-info mime-type"`
+			Applications []Application `xml:"http://www.freedesktop.org/standards/desktop-bookmarks applications>application"`
+		} `xml:"info>metadata"`
+	} `xml:"bookmark"`
+}
+
+// Path returns the path of the recently used files under $XDG_DATA_HOME or, when it is not set, under
+// ~/.local/share.
+func Path() string {
+	if dataDir := os.Getenv("XDG_DATA_HOME"); dataDir != "" {
+		return filepath.Join(dataDir, recentFile)
+	}
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".local", "share", recentFile)
+}
+
+// NewReader returns the reader of the file at the path, which is resolved on every load.
+func NewReader(path func() string) *Reader {
+	return &Reader{path: path}
+}
+
+// Load reads the bookmarks from the file of the reader.
+func (r *Reader) Load() ([]*Bookmark, error) {
+	return ParseFile(r.path())
+}
+
+// ParseFile reads the bookmarks from the file. A missing file has none.
+func ParseFile(path string) ([]*Bookmark, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	bookmarks, err := Parse(file)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "Could not parse %s", path)
+	}
+	return bookmarks, nil
+}
+
+// Parse reads the bookmarks from the XBEL document.
+func Parse(reader io.Reader) ([]*Bookmark, error) {
+	var doc document
+	if err := xml.NewDecoder(reader).Decode(&doc); err != nil {
+		return nil, err
+	}
+	bookmarks := make([]*Bookmark, 0, len(doc.Bookmarks))
+	for _, bookmark := range doc.Bookmarks {
+		bookmarks = append(bookmarks, &Bookmark{
+			Href:         bookmark.Href,
+			Added:        bookmark.Added,
+			Modified:     bookmark.Modified,
+			Visited:      bookmark.Visited,
+			MimeType:     bookmark.Metadata.MimeType.Type,
+			Applications: bookmark.Metadata.Applications,
+		})
+	}
+	return bookmarks, nil
+}
+
+// LocalPath returns the path of the bookmarked file, or false when it is not a local file.
+func (b *Bookmark) LocalPath() (string, bool) {
+	parsedUrl, err := url.Parse(b.Href)
+	if err != nil || parsedUrl.Scheme != "file" || (parsedUrl.Host != "" && parsedUrl.Host != "localhost") {
+		return "", false
+	}
+	return parsedUrl.Path, true
+}
+
+// LastUsed returns when the file was last added, modified or visited by any application.
+func (b *Bookmark) LastUsed() time.Time {
+	lastUsed := b.Added
+	for _, used := range []time.Time{b.Modified, b.Visited} {
+		if used.After(lastUsed) {
+			lastUsed = used
+		}
+	}
+	for _, application := range b.Applications {
+		if application.Modified.After(lastUsed) {
+			lastUsed = application.Modified
+		}
+	}
+	return lastUsed
+}
+
+// MatchesMimeType reports whether the MIME type of the file matches any of the patterns, e.g. "text/*". Any file
+// matches when there are no patterns.
+func (b *Bookmark) MatchesMimeType(patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		matched, _ := path.Match(pattern, b.MimeType)
+		return matched
+	})
+}
+
+// UsedByAny reports whether the file was used by any of the applications. Any file matches when there are no
+// applications.
+func (b *Bookmark) UsedByAny(names []string) bool {
+	if len(names) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(b.Applications, func(application Application) bool {
+		return slices.Contains(names, application.Name)
+	})
+}
@@ -0,0 +1,230 @@
+/*
+ * © 2025-2025 JDHeim.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xbel
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const recentlyUsed = `<?xml version="1.0" encoding="UTF-8"?>
+<xbel version="1.0"
+      xmlns:bookmark="http://www.freedesktop.org/standards/desktop-bookmarks"
+      xmlns:mime="http://www.freedesktop.org/standards/shared-mime-info"
+>
+  <bookmark href="file:///home/user/notes.txt" added="2025-01-01T10:00:00.000000Z" modified="2025-01-02T10:00:00.000000Z" visited="2025-01-01T10:00:00.000000Z">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="text/plain"/>
+        <bookmark:applications>
+          <bookmark:application name="gedit" exec="&apos;gedit %u&apos;" modified="2025-01-03T10:00:00.000000Z" count="2"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+  <bookmark href="file:///home/user/My%20Photo.png" added="2025-01-04T10:00:00Z" modified="2025-01-04T10:00:00Z" visited="2025-01-04T10:00:00Z">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="image/png"/>
+        <bookmark:applications>
+          <bookmark:application name="Image Viewer" exec="&apos;eog %u&apos;" modified="2025-01-04T10:00:00Z" count="1"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+</xbel>
+`
+
+func TestPath(t *testing.T) {
+	homeDir, _ := os.UserHomeDir()
+	testCases := map[string]struct {
+		xdgDataHome string
+		want        string
+	}{
+		"xdg data home": {"/data", filepath.Join("/data", "recently-used.xbel")},
+		"home":          {"", filepath.Join(homeDir, ".local", "share", "recently-used.xbel")},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("XDG_DATA_HOME", testCase.xdgDataHome)
+			if got := Path(); got != testCase.want {
+				t.Errorf("Path() = %s, want %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	day := func(day int) time.Time { return time.Date(2025, 1, day, 10, 0, 0, 0, time.UTC) }
+	want := []*Bookmark{
+		{
+			Href: "file:///home/user/notes.txt", Added: day(1), Modified: day(2), Visited: day(1), MimeType: "text/plain",
+			Applications: []Application{{Name: "gedit", Exec: "'gedit %u'", Modified: day(3), Count: 2}},
+		},
+		{
+			Href: "file:///home/user/My%20Photo.png", Added: day(4), Modified: day(4), Visited: day(4), MimeType: "image/png",
+			Applications: []Application{{Name: "Image Viewer", Exec: "'eog %u'", Modified: day(4), Count: 1}},
+		},
+	}
+
+	got, err := Parse(strings.NewReader(recentlyUsed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse() = diff -want +got\n%s", diff)
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	testCases := map[string]struct {
+		in        *string
+		wantCount int
+		wantErr   bool
+	}{
+		"missing":   {nil, 0, false},
+		"corrupted": {ptr("<xbel><bookmark"), 0, true},
+		"valid":     {ptr(recentlyUsed), 2, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "recently-used.xbel")
+			if testCase.in != nil {
+				if err := os.WriteFile(path, []byte(*testCase.in), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := ParseFile(path)
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("ParseFile() = %v, want error %t", err, testCase.wantErr)
+			}
+			if len(got) != testCase.wantCount {
+				t.Errorf("ParseFile() = %d bookmarks, want %d", len(got), testCase.wantCount)
+			}
+		})
+	}
+}
+
+func TestBookmark_LocalPath(t *testing.T) {
+	testCases := map[string]struct {
+		href      string
+		want      string
+		wantLocal bool
+	}{
+		"file":      {"file:///home/user/notes.txt", "/home/user/notes.txt", true},
+		"escaped":   {"file:///home/user/My%20Photo.png", "/home/user/My Photo.png", true},
+		"localhost": {"file://localhost/home/user/notes.txt", "/home/user/notes.txt", true},
+		"remote":    {"file://server/home/user/notes.txt", "", false},
+		"https":     {"https://jdheim.com", "", false},
+		"sftp":      {"sftp://server/notes.txt", "", false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, gotLocal := (&Bookmark{Href: testCase.href}).LocalPath()
+			if got != testCase.want || gotLocal != testCase.wantLocal {
+				t.Errorf("LocalPath() = %s, %t, want %s, %t", got, gotLocal, testCase.want, testCase.wantLocal)
+			}
+		})
+	}
+}
+
+func TestBookmark_LastUsed(t *testing.T) {
+	day := func(day int) time.Time { return time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC) }
+	testCases := map[string]struct {
+		bookmark *Bookmark
+		want     time.Time
+	}{
+		"added":       {&Bookmark{Added: day(3), Modified: day(1), Visited: day(2)}, day(3)},
+		"modified":    {&Bookmark{Added: day(1), Modified: day(3), Visited: day(2)}, day(3)},
+		"visited":     {&Bookmark{Added: day(1), Modified: day(2), Visited: day(3)}, day(3)},
+		"application": {&Bookmark{Added: day(1), Applications: []Application{{Modified: day(4)}, {Modified: day(2)}}}, day(4)},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := testCase.bookmark.LastUsed(); !got.Equal(testCase.want) {
+				t.Errorf("LastUsed() = %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestBookmark_MatchesMimeType(t *testing.T) {
+	testCases := map[string]struct {
+		patterns []string
+		want     bool
+	}{
+		"none":     {nil, true},
+		"exact":    {[]string{"image/png"}, true},
+		"wildcard": {[]string{"text/*", "image/*"}, true},
+		"other":    {[]string{"text/*"}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := (&Bookmark{MimeType: "image/png"}).MatchesMimeType(testCase.patterns); got != testCase.want {
+				t.Errorf("MatchesMimeType() = %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestBookmark_UsedByAny(t *testing.T) {
+	bookmark := &Bookmark{Applications: []Application{{Name: "gedit"}, {Name: "code"}}}
+	testCases := map[string]struct {
+		names []string
+		want  bool
+	}{
+		"none":  {nil, true},
+		"one":   {[]string{"code"}, true},
+		"other": {[]string{"vim"}, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := bookmark.UsedByAny(testCase.names); got != testCase.want {
+				t.Errorf("UsedByAny() = %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestReader_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recently-used.xbel")
+	if err := os.WriteFile(path, []byte(recentlyUsed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewReader(func() string { return path }).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Load() = %d bookmarks, want 2", len(got))
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/jdheim/launchee/build"
 	"github.com/jdheim/launchee/cmd"
+	"github.com/jdheim/launchee/internal/ipc"
 	flag "github.com/spf13/pflag"
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/logger"
@@ -32,6 +33,11 @@ import (
 	"github.com/wailsapp/wails/v2/pkg/options/linux"
 )
 
+const (
+	commandPrintConfig = "print-config"
+	commandMigrate     = "migrate"
+)
+
 //go:embed all:frontend/dist
 var assets embed.FS
 
@@ -46,17 +52,31 @@ func processCommandLineFlags() {
 	help := flag.BoolP("help", "h", false, "Show help")
 	version := flag.BoolP("version", "v", false, "Show version")
 	customConfigPath := flag.StringP("config", "c", "", "Set custom config path, e.g. `/tmp/launchee.yml`")
+	id := flag.Int("id", -1, "Set the id of the shortcut to run, e.g. `3`")
+	profile := flag.StringP("profile", "p", "", "Set the profile of shortcuts, e.g. `dev`")
+	printSchema := flag.Bool("print-schema", false, "Print the JSON Schema of the config for editors")
 
 	parse()
 
 	switch {
 	case *help:
 		fmt.Printf("Launchee - clean, minimalist dock for launching your essential shortcuts\n\n")
+		fmt.Printf("Commands:\n")
+		fmt.Printf("  run <name>       Launch the shortcut with the given name without opening the dock\n")
+		fmt.Printf("  run --id <id>    Launch the shortcut with the given id without opening the dock\n")
+		fmt.Printf("  show             Show the running dock\n")
+		fmt.Printf("  hide             Hide the running dock\n")
+		fmt.Printf("  toggle           Show or hide the running dock\n")
+		fmt.Printf("  reload           Reload the config of the running dock\n")
+		fmt.Printf("  print-config     Print the shortcuts of the config and the ones skipped on this machine\n")
+		fmt.Printf("  migrate          Rewrite the user config, or the one given by --config, in the current version\n\n")
 		flag.Usage()
 		os.Exit(0)
 	case *version:
 		fmt.Println("Launchee version:", cmd.NewLaunchee().GetAppVersion())
 		os.Exit(0)
+	case *printSchema:
+		os.Exit(cmd.PrintSchema(os.Stdout))
 	case *customConfigPath != "":
 		if _, err := os.Stat(*customConfigPath); err != nil {
 			_, _ = fmt.Fprintln(os.Stderr, err)
@@ -64,6 +84,58 @@ func processCommandLineFlags() {
 		}
 		cmd.NewLaunchee().SetCustomConfigPath(*customConfigPath)
 	}
+	cmd.SetProfile(*profile)
+
+	switch command := flag.Arg(0); command {
+	case ipc.CommandRun:
+		runShortcut(flag.Arg(1), *id)
+	case "", ipc.CommandShow, ipc.CommandToggle:
+		// Without a running dock, a new one is started
+		if command == "" {
+			command = ipc.CommandShow
+		}
+		forward(&ipc.Request{Command: command, Id: -1, Profile: *profile}, "config")
+	case commandPrintConfig:
+		os.Exit(cmd.PrintConfig(os.Stdout))
+	case commandMigrate:
+		os.Exit(cmd.Migrate(os.Stdout))
+	case ipc.CommandHide, ipc.CommandReload:
+		forward(&ipc.Request{Command: command, Id: -1}, "config", "profile")
+		_, _ = fmt.Fprintln(os.Stderr, ipc.ErrNotRunning)
+		os.Exit(cmd.ExitCodeNotRunning)
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "Unknown command \"%s\"\n", command)
+		os.Exit(cmd.ExitCodeUsage)
+	}
+}
+
+// runShortcut launches the shortcut in the running dock or, when there is none, without opening the dock and exits.
+func runShortcut(name string, id int) {
+	if (name == "") == (id < 0) || flag.NArg() > 2 {
+		_, _ = fmt.Fprintln(os.Stderr, "run requires either a shortcut name or --id")
+		os.Exit(cmd.ExitCodeUsage)
+	}
+	forward(&ipc.Request{Command: ipc.CommandRun, Name: name, Id: id}, "config", "profile")
+	os.Exit(cmd.RunHeadless(name, id))
+}
+
+// forward sends the request to the running dock and exits. It returns only when there is no running dock. The flags
+// the request does not carry are rejected, as the running dock could not honor them.
+func forward(request *ipc.Request, unforwardedFlags ...string) {
+	for _, name := range unforwardedFlags {
+		if flag.CommandLine.Changed(name) && cmd.IsInstanceRunning() {
+			_, _ = fmt.Fprintf(os.Stderr, "--%s cannot be used while the dock is running\n", name)
+			os.Exit(cmd.ExitCodeUsage)
+		}
+	}
+	forwarded, err := cmd.ForwardToRunningInstance(request)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(cmd.ForwardedExitCode(err))
+	}
+	if forwarded {
+		os.Exit(cmd.ExitCodeOk)
+	}
 }
 
 func startGUI() {
@@ -78,6 +150,7 @@ func startGUI() {
 		LogLevel:           logger.INFO,
 		LogLevelProduction: logger.ERROR,
 		OnStartup:          launchee.Startup,
+		OnShutdown:         launchee.Shutdown,
 		Bind: []interface{}{
 			launchee,
 		},
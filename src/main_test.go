@@ -18,12 +18,15 @@ package main
 
 import (
 	"errors"
+	"os"
 	"testing"
 
 	"github.com/jdheim/launchee/cmd"
 	"github.com/jdheim/launchee/internal/config/frontend"
+	"github.com/jdheim/launchee/internal/ipc"
 	"github.com/jdheim/launchee/internal/test/assert"
 	"github.com/jdheim/launchee/internal/test/stub"
+	"github.com/jdheim/launchee/internal/test/tempdir"
 	flag "github.com/spf13/pflag"
 )
 
@@ -54,11 +57,32 @@ func TestProcessCommandLineFlags(t *testing.T) {
 		"config long":             {[]string{"--config", stub.ConfigPathValidStub{}.GetSystemConfigPath()}, -1},
 		"invalid config short":    {[]string{"-c", stub.ConfigPathNotExistsStub{}.GetSystemConfigPath()}, 1},
 		"invalid config long":     {[]string{"--config", stub.ConfigPathNotExistsStub{}.GetSystemConfigPath()}, 1},
+		"run unknown name":        {[]string{"run", "Unknown", "-c", stub.ConfigPathValidStub{}.GetSystemConfigPath()}, 3},
+		"run unknown id":          {[]string{"run", "--id", "100", "-c", stub.ConfigPathValidStub{}.GetSystemConfigPath()}, 3},
+		"run invalid config":      {[]string{"run", "Terminal", "-c", stub.ConfigPathInvalidStub{}.GetSystemConfigPath()}, 1},
+		"run unknown profile":     {[]string{"run", "Terminal", "-p", "meetings", "-c", stub.ConfigPathValidStub{}.GetSystemConfigPath()}, 1},
+		"run profile shortcut":    {[]string{"run", "Status", "--profile", "ops", "-c", stub.ConfigPathValidStub{}.GetSystemConfigPath()}, 0},
+		"profile short":           {[]string{"-p", "ops"}, -1},
+		"profile long":            {[]string{"--profile", "ops"}, -1},
+		"run without shortcut":    {[]string{"run"}, 2},
+		"run name and id":         {[]string{"run", "Terminal", "--id", "0"}, 2},
+		"run too many args":       {[]string{"run", "Terminal", "Firefox"}, 2},
+		"print config":            {[]string{"print-config", "-c", stub.ConfigPathValidStub{}.GetSystemConfigPath()}, 0},
+		"print invalid config":    {[]string{"print-config", "-c", stub.ConfigPathInvalidStub{}.GetSystemConfigPath()}, 1},
+		"print schema":            {[]string{"--print-schema"}, 0},
+		"migrate invalid config":  {[]string{"migrate", "-c", stub.ConfigPathInvalidStub{}.GetSystemConfigPath()}, 1},
+		"show":                    {[]string{"show"}, -1},
+		"toggle":                  {[]string{"toggle"}, -1},
+		"hide not running":        {[]string{"hide"}, 5},
+		"reload not running":      {[]string{"reload"}, 5},
+		"unknown command":         {[]string{"unknown"}, 2},
 		"incorrect flag short":    {[]string{"-e"}, 2},
 		"incorrect flag long":     {[]string{"--error"}, 2},
 	}
 
+	useTestRuntimeDir(t)
 	t.Cleanup(func() { parse = flag.Parse })
+	t.Cleanup(func() { cmd.SetProfile("") })
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
 			flag.CommandLine = flag.NewFlagSet(t.Name(), flag.ExitOnError)
@@ -81,6 +105,74 @@ func TestProcessCommandLineFlags(t *testing.T) {
 	}
 }
 
+func TestForwardToRunningInstance(t *testing.T) {
+	testCases := map[string]struct {
+		input    []string
+		exitCode int
+	}{
+		"launch":        {[]string{}, 0},
+		"show":          {[]string{"show"}, 0},
+		"hide":          {[]string{"hide"}, 0},
+		"toggle":        {[]string{"toggle"}, 0},
+		"reload":        {[]string{"reload"}, 0},
+		"run":           {[]string{"run", "Terminal"}, 0},
+		"unknown":       {[]string{"run", "Unknown"}, 3},
+		"launch failed": {[]string{"run", "Invalid"}, 4},
+		"error":         {[]string{"run", "Refused"}, 4},
+		"show profile":  {[]string{"show", "--profile", "ops"}, 0},
+		"show config":   {[]string{"show", "--config", stub.ConfigPathValidStub{}.GetSystemConfigPath()}, 2},
+		"hide profile":  {[]string{"hide", "-p", "ops"}, 2},
+		"reload config": {[]string{"reload", "-c", stub.ConfigPathValidStub{}.GetSystemConfigPath()}, 2},
+		"run profile":   {[]string{"run", "Terminal", "--profile", "ops"}, 2},
+	}
+
+	useTestRuntimeDir(t)
+	// The tests exit in a subprocess, where the dock of the parent process is already running
+	if server, err := ipc.Listen(ipc.SocketPath(), func(request *ipc.Request) error {
+		switch request.Name {
+		case "Unknown":
+			return ipc.WithStatus(ipc.StatusUnknownShortcut, errors.New("Shortcut not found"))
+		case "Invalid":
+			return ipc.WithStatus(ipc.StatusLaunchFailed, errors.New("executable file not found"))
+		case "Refused":
+			return errors.New("Refused")
+		}
+		return nil
+	}); err == nil {
+		t.Cleanup(func() { _ = server.Close() })
+	}
+	t.Cleanup(func() { parse = flag.Parse })
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			flag.CommandLine = flag.NewFlagSet(t.Name(), flag.ExitOnError)
+			if err := assert.FuncExited(t, &assert.TestedFunc{
+				FunctionName: "processCommandLineFlags",
+				Function: func() {
+					parse = testParse(t, testCase.input)
+					processCommandLineFlags()
+				},
+				ExitCode: testCase.exitCode,
+			}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+const testRuntimeDirEnv = "LAUNCHEE_TEST_RUNTIME_DIR"
+
+// useTestRuntimeDir points the IPC socket and the usage stats to a temp dir, which is inherited by the subprocesses.
+func useTestRuntimeDir(t *testing.T) {
+	t.Helper()
+	if os.Getenv(testRuntimeDirEnv) != "" {
+		return
+	}
+	dir := tempdir.Short(t)
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+	t.Setenv("XDG_DATA_HOME", dir)
+	t.Setenv(testRuntimeDirEnv, dir)
+}
+
 func testParse(t *testing.T, input []string) func() {
 	t.Helper()
 	return func() {